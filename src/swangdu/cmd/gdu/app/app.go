@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -8,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"net/http"
 	"net/http/pprof"
@@ -29,23 +31,32 @@ import (
 type UI interface {
 	ListDevices(getter device.DevicesInfoGetter) error
 	AnalyzePath(path string, parentDir gfs.Item) error
-	ReadAnalysis(input io.Reader) error
+	ReadAnalysis(inputs ...io.Reader) error
 	SetIgnoreDirPaths(paths []string)
 	SetIgnoreDirPatterns(paths []string) error
 	SetIgnoreFromFile(ignoreFile string) error
 	SetIgnoreHidden(value bool)
+	SetFollowSymlinks(value bool)
+	SetKeepInputPaths(value bool)
 	StartUILoop() error
 }
 
 // Flags define flags accepted by Run
 type Flags struct {
 	LogFile           string
-	InputFile         string
 	OutputFile        string
+	OutputFormat      string
+	HTMLFile          string
+	FoldedFile        string
+	InputFiles        []string
 	IgnoreDirs        []string
 	IgnoreDirPatterns []string
 	IgnoreFromFile    string
+	StartAt           string
 	MaxCores          int
+	MaxDepth          int
+	OlderThan         int
+	TopFiles          int
 	ShowDisks         bool
 	ShowApparentSize  bool
 	ShowRelativeSize  bool
@@ -55,9 +66,15 @@ type Flags struct {
 	NoProgress        bool
 	NoCross           bool
 	NoHidden          bool
+	FollowSymlinks    bool
 	Profiling         bool
 	ConstGC           bool
 	Summarize         bool
+	ByOwner           bool
+	ByType            bool
+	FindDuplicates    bool
+	BrokenLinks       bool
+	KeepInputPaths    bool
 	UseSIPrefix       bool
 }
 
@@ -136,6 +153,10 @@ func (a *App) Run() (err error) {
 		ui.SetIgnoreHidden(true)
 	}
 
+	if a.Flags.FollowSymlinks {
+		ui.SetFollowSymlinks(true)
+	}
+
 	a.setMaxProcs()
 
 	if err = a.runAction(ui, path); err != nil {
@@ -167,18 +188,26 @@ func (a *App) setMaxProcs() {
 func (a *App) createUI() (UI, error) {
 	var ui UI
 
-	if a.Flags.OutputFile != "" {
+	outputFile, format, err := a.getExportFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if outputFile != "" {
 		var output io.Writer
-		var err error
-		if a.Flags.OutputFile == "-" {
+		if outputFile == "-" {
 			output = os.Stdout
 		} else {
-			output, err = os.OpenFile(a.Flags.OutputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 			if err != nil {
 				return nil, fmt.Errorf("opening output file: %w", err)
 			}
+			output, err = report.CreateCompressedOutput(file, outputFile)
+			if err != nil {
+				return nil, fmt.Errorf("compressing output file: %w", err)
+			}
 		}
-		ui = report.CreateExportUI(
+		exportUI := report.CreateExportUI(
 			a.Writer,
 			output,
 			!a.Flags.NoColor && a.Istty,
@@ -186,11 +215,14 @@ func (a *App) createUI() (UI, error) {
 			a.Flags.ConstGC,
 			a.Flags.UseSIPrefix,
 		)
-		return ui, nil
+		exportUI.SetFormat(format)
+		exportUI.SetShowApparentSize(a.Flags.ShowApparentSize)
+		exportUI.SetMaxDepth(a.Flags.MaxDepth)
+		return exportUI, nil
 	}
 
 	if a.Flags.NonInteractive || !a.Istty {
-		ui = stdout.CreateStdoutUI(
+		stdoutUI := stdout.CreateStdoutUI(
 			a.Writer,
 			!a.Flags.NoColor && a.Istty,
 			!a.Flags.NoProgress && a.Istty,
@@ -200,8 +232,15 @@ func (a *App) createUI() (UI, error) {
 			a.Flags.ConstGC,
 			a.Flags.UseSIPrefix,
 		)
+		stdoutUI.SetShowByOwner(a.Flags.ByOwner)
+		stdoutUI.SetShowByType(a.Flags.ByType)
+		stdoutUI.SetShowDuplicates(a.Flags.FindDuplicates)
+		stdoutUI.SetShowBrokenLinks(a.Flags.BrokenLinks)
+		stdoutUI.SetTopFiles(a.Flags.TopFiles)
+		stdoutUI.SetOlderThan(time.Duration(a.Flags.OlderThan) * 24 * time.Hour)
+		ui = stdoutUI
 	} else {
-		ui = tui.CreateUI(
+		tuiUI := tui.CreateUI(
 			a.TermApp,
 			a.Screen,
 			os.Stdout,
@@ -211,6 +250,8 @@ func (a *App) createUI() (UI, error) {
 			a.Flags.ConstGC,
 			a.Flags.UseSIPrefix,
 		)
+		tuiUI.SetStartAt(a.Flags.StartAt)
+		ui = tuiUI
 
 		if !a.Flags.NoColor {
 			tview.Styles.TitleColor = tcell.NewRGBColor(27, 161, 227)
@@ -220,6 +261,45 @@ func (a *App) createUI() (UI, error) {
 	return ui, nil
 }
 
+// IsExport returns true if export of the analysis into a file was requested
+func (f *Flags) IsExport() bool {
+	return f.OutputFile != "" || f.HTMLFile != "" || f.FoldedFile != ""
+}
+
+// getExportFile returns file and format of the export, empty file if no export was requested
+func (a *App) getExportFile() (string, string, error) {
+	format := a.Flags.OutputFormat
+	switch format {
+	case "", report.FormatJSON:
+		// JSON is the default format, binary snapshot is chosen by extension of the file
+		format = report.FormatJSON
+		if report.IsSnapshotFile(a.Flags.OutputFile) {
+			format = report.FormatSnapshot
+		}
+	case report.FormatCSV, report.FormatHTML, report.FormatFolded, report.FormatSnapshot:
+	default:
+		return "", "", fmt.Errorf("unknown output format: %s", format)
+	}
+
+	exports := []struct{ file, format string }{
+		{a.Flags.OutputFile, format},
+		{a.Flags.HTMLFile, report.FormatHTML},
+		{a.Flags.FoldedFile, report.FormatFolded},
+	}
+
+	var outputFile string
+	for _, export := range exports {
+		if export.file == "" {
+			continue
+		}
+		if outputFile != "" {
+			return "", "", errors.New("only one of --output-file, --html and --folded can be used")
+		}
+		outputFile, format = export.file, export.format
+	}
+	return outputFile, format, nil
+}
+
 func (a *App) setNoCross(path string) error {
 	if a.Flags.NoCross {
 		mounts, err := a.Getter.GetMounts()
@@ -249,19 +329,22 @@ func (a *App) runAction(ui UI, path string) error {
 		if err := ui.ListDevices(a.Getter); err != nil {
 			return fmt.Errorf("loading mount points: %w", err)
 		}
-	} else if a.Flags.InputFile != "" {
-		var input io.Reader
-		var err error
-		if a.Flags.InputFile == "-" {
-			input = os.Stdin
-		} else {
-			input, err = os.OpenFile(a.Flags.InputFile, os.O_RDONLY, 0600)
+	} else if len(a.Flags.InputFiles) > 0 {
+		inputs := make([]io.Reader, 0, len(a.Flags.InputFiles))
+		for _, inputFile := range a.Flags.InputFiles {
+			if inputFile == "-" {
+				inputs = append(inputs, os.Stdin)
+				continue
+			}
+			input, err := os.OpenFile(inputFile, os.O_RDONLY, 0600)
 			if err != nil {
 				return fmt.Errorf("opening input file: %w", err)
 			}
+			inputs = append(inputs, input)
 		}
 
-		if err := ui.ReadAnalysis(input); err != nil {
+		ui.SetKeepInputPaths(a.Flags.KeepInputPaths)
+		if err := ui.ReadAnalysis(inputs...); err != nil {
 			return fmt.Errorf("reading analysis: %w", err)
 		}
 	} else {
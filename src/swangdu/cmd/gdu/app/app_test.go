@@ -119,6 +119,103 @@ func TestAnalyzePathWithIgnoringFromNotExistingFile(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestAnalyzePathByOwner(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", ByOwner: true},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.Contains(t, out, "Users:")
+	assert.NotContains(t, out, "nested")
+	assert.Nil(t, err)
+}
+
+func TestAnalyzePathByType(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", ByType: true},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.Contains(t, out, "Extensions:")
+	assert.NotContains(t, out, "nested")
+	assert.Nil(t, err)
+}
+
+func TestAnalyzePathFindDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", FindDuplicates: true},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.Contains(t, out, "Total reclaimable: 0 B")
+	assert.Nil(t, err)
+}
+
+func TestAnalyzePathFollowSymlinks(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.Symlink("nested/subnested", "test_dir/link")
+	assert.Nil(t, err)
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", FollowSymlinks: true},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.Contains(t, out, "nested")
+	assert.Contains(t, out, "@ ")
+	assert.Nil(t, err)
+}
+
+func TestAnalyzePathOlderThan(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", OlderThan: 1},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.NotContains(t, out, "nested")
+	assert.Nil(t, err)
+}
+
+func TestAnalyzePathTopFiles(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", TopFiles: 1, ShowApparentSize: true},
+		[]string{"test_dir"},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+
+	assert.Contains(t, out, "test_dir/nested/subnested/file")
+	assert.NotContains(t, out, "file2")
+	assert.Nil(t, err)
+}
+
 func TestAnalyzePathWithGui(t *testing.T) {
 	fin := testdir.CreateTestDir()
 	defer fin()
@@ -152,9 +249,171 @@ func TestAnalyzePathWithExport(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAnalyzePathWithHTMLExport(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	defer func() {
+		os.Remove("output.html")
+	}()
+
+	_, err := runApp(
+		&Flags{LogFile: "/dev/null", HTMLFile: "output.html"},
+		[]string{"test_dir"},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile("output.html")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"n":"nested"`)
+}
+
+func TestAnalyzePathWithFoldedExport(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	defer func() {
+		os.Remove("output.folded")
+	}()
+
+	_, err := runApp(
+		&Flags{LogFile: "/dev/null", FoldedFile: "output.folded", ShowApparentSize: true},
+		[]string{"test_dir"},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile("output.folded")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), ";nested;subnested;file 5\n")
+}
+
+func TestAnalyzePathWithCSVExport(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	defer func() {
+		os.Remove("output.csv")
+	}()
+
+	_, err := runApp(
+		&Flags{LogFile: "/dev/null", OutputFile: "output.csv", OutputFormat: "csv"},
+		[]string{"test_dir"},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile("output.csv")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "/test_dir/nested/subnested/file,3,file,5,")
+}
+
+func TestAnalyzePathWithUnknownExportFormat(t *testing.T) {
+	_, err := runApp(
+		&Flags{LogFile: "/dev/null", OutputFile: "output.xml", OutputFormat: "xml"},
+		[]string{"test_dir"},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.ErrorContains(t, err, "unknown output format: xml")
+}
+
+func TestAnalyzePathWithBothExports(t *testing.T) {
+	_, err := runApp(
+		&Flags{LogFile: "/dev/null", OutputFile: "output.json", HTMLFile: "output.html"},
+		[]string{"test_dir"},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.ErrorContains(t, err, "only one of")
+}
+
+func TestIsExport(t *testing.T) {
+	assert.False(t, (&Flags{}).IsExport())
+	assert.True(t, (&Flags{OutputFile: "output.json"}).IsExport())
+	assert.True(t, (&Flags{HTMLFile: "output.html"}).IsExport())
+	assert.True(t, (&Flags{FoldedFile: "output.folded"}).IsExport())
+}
+
+func TestExportAndImportByExtension(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	for _, name := range []string{"output.json.gz", "output.json.zst", "output.gdub", "output.gdub.zst"} {
+		_, err := runApp(
+			&Flags{LogFile: "/dev/null", OutputFile: name},
+			[]string{"test_dir"},
+			true,
+			testdev.DevicesInfoGetterMock{},
+		)
+		assert.Nil(t, err)
+
+		out, err := runApp(
+			&Flags{LogFile: "/dev/null", InputFiles: []string{name}},
+			[]string{},
+			false,
+			testdev.DevicesInfoGetterMock{},
+		)
+		assert.Nil(t, err)
+		assert.Contains(t, out, "nested")
+
+		content, err := os.ReadFile(name)
+		assert.Nil(t, err)
+		assert.NotContains(t, string(content), `"name"`)
+
+		os.Remove(name)
+	}
+}
+
+func TestReadMergedAnalysesFromFiles(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	for _, name := range []string{"first.json", "second.gdub"} {
+		_, err := runApp(
+			&Flags{LogFile: "/dev/null", OutputFile: name},
+			[]string{"test_dir"},
+			true,
+			testdev.DevicesInfoGetterMock{},
+		)
+		assert.Nil(t, err)
+		defer os.Remove(name)
+	}
+
+	out, err := runApp(
+		&Flags{LogFile: "/dev/null", InputFiles: []string{"first.json", "second.gdub"}},
+		[]string{},
+		false,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "test_dir\n")
+	assert.Contains(t, out, "test_dir (2)")
+
+	_, err = runApp(
+		&Flags{
+			LogFile:        "/dev/null",
+			InputFiles:     []string{"first.json", "second.gdub"},
+			OutputFile:     "merged.json",
+			KeepInputPaths: true,
+		},
+		[]string{},
+		true,
+		testdev.DevicesInfoGetterMock{},
+	)
+	assert.Nil(t, err)
+	defer os.Remove("merged.json")
+
+	content, err := os.ReadFile("merged.json")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"name":"/"`)
+	assert.Contains(t, string(content), `"name":"test_dir (2)"`)
+}
+
 func TestReadAnalysisFromFile(t *testing.T) {
 	out, err := runApp(
-		&Flags{LogFile: "/dev/null", InputFile: "../../../internal/testdata/test.json"},
+		&Flags{LogFile: "/dev/null", InputFiles: []string{"../../../internal/testdata/test.json"}},
 		[]string{"test_dir"},
 		false,
 		testdev.DevicesInfoGetterMock{},
@@ -167,7 +426,7 @@ func TestReadAnalysisFromFile(t *testing.T) {
 
 func TestReadWrongAnalysisFromFile(t *testing.T) {
 	out, err := runApp(
-		&Flags{LogFile: "/dev/null", InputFile: "../../../internal/testdata/wrong.json"},
+		&Flags{LogFile: "/dev/null", InputFiles: []string{"../../../internal/testdata/wrong.json"}},
 		[]string{"test_dir"},
 		false,
 		testdev.DevicesInfoGetterMock{},
@@ -179,7 +438,7 @@ func TestReadWrongAnalysisFromFile(t *testing.T) {
 
 func TestReadWrongAnalysisFromNotExistingFile(t *testing.T) {
 	out, err := runApp(
-		&Flags{LogFile: "/dev/null", InputFile: "xxx.json"},
+		&Flags{LogFile: "/dev/null", InputFiles: []string{"xxx.json"}},
 		[]string{"test_dir"},
 		false,
 		testdev.DevicesInfoGetterMock{},
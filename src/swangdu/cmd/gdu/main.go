@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -32,8 +33,13 @@ func init() {
 	af = &app.Flags{}
 	flags := rootCmd.Flags()
 	flags.StringVarP(&af.LogFile, "log-file", "l", "/dev/null", "Path to a logfile")
-	flags.StringVarP(&af.OutputFile, "output-file", "o", "", "Export all info into file as JSON")
-	flags.StringVarP(&af.InputFile, "input-file", "f", "", "Import analysis from JSON file")
+	flags.StringVarP(&af.OutputFile, "output-file", "o", "", "Export all info into file as JSON (compressed if the name ends with .gz or .zst)")
+	flags.StringVar(&af.OutputFormat, "output-format", "json", "Format of the exported file (json, csv, html, folded or snapshot)")
+	flags.StringVar(&af.HTMLFile, "html", "", "Export all info into file as interactive HTML report")
+	flags.StringVar(&af.FoldedFile, "folded", "", "Export all info into file as folded stacks for flame graph tools")
+	flags.IntVar(&af.MaxDepth, "max-depth", 0, "Aggregate items deeper than given depth into their parent directory in folded stacks export")
+	flags.StringArrayVarP(&af.InputFiles, "input-file", "f", []string{}, "Import analysis from JSON file (gzip and zstd compressed files are detected), repeat to merge several analyses")
+	flags.BoolVar(&af.KeepInputPaths, "keep-paths", false, "Place merged analyses at their original absolute paths instead of under synthetic root")
 	flags.IntVarP(&af.MaxCores, "max-cores", "m", runtime.NumCPU(), fmt.Sprintf("Set max cores that GDU will use. %d cores available", runtime.NumCPU()))
 	flags.BoolVarP(&af.ShowVersion, "version", "v", false, "Print version")
 
@@ -42,6 +48,7 @@ func init() {
 	flags.StringVarP(&af.IgnoreFromFile, "ignore-from", "X", "", "Read absolute path patterns to ignore from file")
 	flags.BoolVarP(&af.NoHidden, "no-hidden", "H", false, "Ignore hidden directories (beginning with dot)")
 	flags.BoolVarP(&af.NoCross, "no-cross", "x", false, "Do not cross filesystem boundaries")
+	flags.BoolVarP(&af.FollowSymlinks, "follow-symlinks", "L", false, "Follow symlinks to files and directories and count their targets")
 	flags.BoolVarP(&af.ConstGC, "const-gc", "g", false, "Enable memory garbage collection during analysis with constant level set by GOGC")
 	flags.BoolVar(&af.Profiling, "enable-profiling", false, "Enable collection of profiling data and provide it on http://localhost:6060/debug/pprof/")
 
@@ -52,9 +59,47 @@ func init() {
 	flags.BoolVarP(&af.NonInteractive, "non-interactive", "n", false, "Do not run in interactive mode")
 	flags.BoolVarP(&af.NoProgress, "no-progress", "p", false, "Do not show progress in non-interactive mode")
 	flags.BoolVarP(&af.Summarize, "summarize", "s", false, "Show only a total in non-interactive mode")
+	flags.BoolVar(&af.ByOwner, "by-owner", false, "Show usage by users and groups in non-interactive mode")
+	flags.BoolVar(&af.ByType, "by-type", false, "Show usage by file categories and extensions in non-interactive mode")
+	flags.BoolVar(&af.FindDuplicates, "find-duplicates", false, "Show sets of files with identical content in non-interactive mode")
+	flags.BoolVar(&af.BrokenLinks, "broken-links", false, "List symlinks with missing targets in non-interactive mode")
+	flags.StringVar(&af.StartAt, "start-at", "", "Open given path inside the analyzed directory when the analysis is done")
+	flags.IntVar(&af.TopFiles, "top-files", 0, "List given number of the largest files in the whole tree in non-interactive mode")
+	flags.IntVar(&af.OlderThan, "older-than", 0, "Show only items not modified in given number of days in non-interactive mode")
 	flags.BoolVar(&af.UseSIPrefix, "si", false, "Show sizes with decimal SI prefixes (kB, MB, GB) instead of binary prefixes (KiB, MiB, GiB)")
 }
 
+var mergeCmd = &cobra.Command{
+	Use:   "merge file... -o output_file",
+	Short: "Merge several analyses into one file",
+	Long: `Merge analyses read from JSON files or binary snapshots into one virtual tree and export it.
+
+Every analysis is placed under synthetic root directory or at its original absolute path
+if --keep-paths is given. Sizes and hard links are counted again for the merged tree.
+`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runMerge,
+}
+
+func init() {
+	flags := mergeCmd.Flags()
+	flags.StringVarP(&af.OutputFile, "output-file", "o", "", "Export merged analysis into file as JSON (compressed if the name ends with .gz or .zst)")
+	flags.StringVar(&af.OutputFormat, "output-format", "json", "Format of the exported file (json, csv, html, folded or snapshot)")
+	flags.BoolVar(&af.KeepInputPaths, "keep-paths", false, "Place merged analyses at their original absolute paths instead of under synthetic root")
+
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+func runMerge(command *cobra.Command, args []string) error {
+	if af.OutputFile == "" {
+		return errors.New("output file must be given by --output-file")
+	}
+	af.InputFiles = args
+	return runE(command, nil)
+}
+
 func runE(command *cobra.Command, args []string) error {
 	var (
 		termApp *tview.Application
@@ -72,7 +117,7 @@ func runE(command *cobra.Command, args []string) error {
 		af.LogFile = "nul"
 	}
 
-	if !af.ShowVersion && !af.NonInteractive && istty && af.OutputFile == "" {
+	if !af.ShowVersion && !af.NonInteractive && istty && !af.IsExport() {
 		screen, err = tcell.NewScreen()
 		if err != nil {
 			return fmt.Errorf("Error creating screen: %w", err)
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWithoutOutputFile(t *testing.T) {
+	af.OutputFile = ""
+	rootCmd.SetArgs([]string{"merge", "a.json", "b.json"})
+	defer rootCmd.SetArgs(nil)
+
+	err := rootCmd.Execute()
+
+	assert.ErrorContains(t, err, "output file must be given")
+}
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	output := filepath.Join(dir, "merged.json")
+
+	err := os.WriteFile(first, []byte(`[1,2,{"progname":"gdu"},
+		[{"name":"/home/xxx"},{"name":"file","asize":5,"dsize":8}]]`), 0o600)
+	assert.Nil(t, err)
+	err = os.WriteFile(second, []byte(`[1,2,{"progname":"gdu"},
+		[{"name":"/srv/yyy"},{"name":"other","asize":7,"dsize":8}]]`), 0o600)
+	assert.Nil(t, err)
+
+	af.OutputFile = ""
+	rootCmd.SetArgs([]string{"merge", first, second, "-o", output})
+	defer rootCmd.SetArgs(nil)
+
+	err = rootCmd.Execute()
+	assert.Nil(t, err)
+
+	data, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `[{"name":"merged"`)
+	assert.Contains(t, string(data), `{"name":"xxx"`)
+	assert.Contains(t, string(data), `{"name":"file","asize":5,"dsize":8`)
+	assert.Contains(t, string(data), `{"name":"yyy"`)
+	assert.Contains(t, string(data), `{"name":"other","asize":7,"dsize":8`)
+}
@@ -18,4 +18,5 @@ type Analyzer interface {
 	GetProgressChan() chan CurrentProgress
 	GetDoneChan() chan struct{}
 	ResetProgress()
+	SetFollowSymlinks(value bool)
 }
@@ -17,6 +17,18 @@ type UI struct {
 	ShowApparentSize      bool
 	ShowRelativeSize      bool
 	ConstGC               bool
+	KeepInputPaths        bool
+}
+
+// SetFollowSymlinks sets if targets of symlinks should be analyzed
+func (ui *UI) SetFollowSymlinks(value bool) {
+	ui.Analyzer.SetFollowSymlinks(value)
+}
+
+// SetKeepInputPaths sets if analyses read from several files should be placed
+// at their original absolute paths instead of under synthetic root directory
+func (ui *UI) SetKeepInputPaths(value bool) {
+	ui.KeepInputPaths = value
 }
 
 // binary multiplies prefixes (IEC)
@@ -19,6 +19,7 @@ func (a *MockedAnalyzer) AnalyzeDir(
 	dir := &analyze.Dir{
 		File: &analyze.File{
 			Name:  "test_dir",
+			Mode:  040755,
 			Usage: 1e12 + 1,
 			Size:  1e12 + 2,
 			Mtime: time.Date(2021, 8, 27, 22, 23, 24, 0, time.UTC),
@@ -29,35 +30,49 @@ func (a *MockedAnalyzer) AnalyzeDir(
 	dir2 := &analyze.Dir{
 		File: &analyze.File{
 			Name:   "aaa",
+			Mode:   040755,
 			Usage:  1e12 + 1,
 			Size:   1e12 + 2,
 			Mtime:  time.Date(2021, 8, 27, 22, 23, 27, 0, time.UTC),
+			Atime:  time.Date(2021, 8, 27, 22, 23, 24, 0, time.UTC),
 			Parent: dir,
 		},
 	}
 	dir3 := &analyze.Dir{
 		File: &analyze.File{
 			Name:   "bbb",
+			Mode:   040755,
+			UID:    1002,
+			GID:    100,
 			Usage:  1e9 + 1,
 			Size:   1e9 + 2,
 			Mtime:  time.Date(2021, 8, 27, 22, 23, 26, 0, time.UTC),
+			Atime:  time.Date(2021, 8, 27, 22, 23, 25, 0, time.UTC),
 			Parent: dir,
 		},
 	}
 	dir4 := &analyze.Dir{
 		File: &analyze.File{
 			Name:   "ccc",
+			Mode:   040755,
+			UID:    1001,
+			GID:    100,
 			Usage:  1e6 + 1,
 			Size:   1e6 + 2,
 			Mtime:  time.Date(2021, 8, 27, 22, 23, 25, 0, time.UTC),
+			Atime:  time.Date(2021, 8, 27, 22, 23, 26, 0, time.UTC),
 			Parent: dir,
 		},
 	}
 	file := &analyze.File{
 		Name:   "ddd",
+		Mode:   0100644,
+		UID:    1003,
+		GID:    100,
 		Usage:  1e3 + 1,
 		Size:   1e3 + 2,
 		Mtime:  time.Date(2021, 8, 27, 22, 23, 24, 0, time.UTC),
+		Atime:  time.Date(2021, 8, 27, 22, 23, 27, 0, time.UTC),
 		Parent: dir,
 	}
 	dir.Files = fs.Files{dir2, dir3, dir4, file}
@@ -80,6 +95,9 @@ func (a *MockedAnalyzer) GetDoneChan() chan struct{} {
 // ResetProgress does nothing
 func (a *MockedAnalyzer) ResetProgress() {}
 
+// SetFollowSymlinks does nothing
+func (a *MockedAnalyzer) SetFollowSymlinks(value bool) {}
+
 // RemoveItemFromDirWithErr returns error
 func RemoveItemFromDirWithErr(dir fs.Item, file fs.Item) error {
 	return errors.New("Failed")
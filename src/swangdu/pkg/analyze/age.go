@@ -0,0 +1,63 @@
+package analyze
+
+import (
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// Day is duration of one day
+const Day = 24 * time.Hour
+
+// AgeUsage is disk usage of files with modification time in given age range
+type AgeUsage struct {
+	Name      string
+	MinAge    time.Duration
+	Size      int64
+	Usage     int64
+	FileCount int
+}
+
+var ageBuckets = []struct {
+	name   string
+	minAge time.Duration
+}{
+	{"< 1 day", 0},
+	{"< 1 week", Day},
+	{"< 1 month", 7 * Day},
+	{"< 1 year", 30 * Day},
+	{"> 1 year", 365 * Day},
+}
+
+// GetUsageByAge returns usage of files in the whole subtree of the item
+// bucketed by time since their last modification (<1 day, <1 week, <1 month, <1 year, >1 year)
+func GetUsageByAge(item fs.Item, now time.Time) []*AgeUsage {
+	buckets := make([]*AgeUsage, len(ageBuckets))
+	for i, bucket := range ageBuckets {
+		buckets[i] = &AgeUsage{Name: bucket.name, MinAge: bucket.minAge}
+	}
+
+	walk(item, func(entry fs.Item) {
+		if entry.IsDir() {
+			return
+		}
+		size, usage := ownSizes(entry)
+		age := now.Sub(entry.GetMtime())
+
+		i := len(buckets) - 1
+		for i > 0 && age < buckets[i].MinAge {
+			i--
+		}
+		buckets[i].Size += size
+		buckets[i].Usage += usage
+		buckets[i].FileCount++
+	})
+
+	return buckets
+}
+
+// IsOlderThan returns true if the item (or any item inside of the dir)
+// was not modified for at least given duration
+func IsOlderThan(item fs.Item, age time.Duration, now time.Time) bool {
+	return !item.GetMtime().After(now.Add(-age))
+}
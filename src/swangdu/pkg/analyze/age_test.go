@@ -0,0 +1,54 @@
+package analyze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestGetUsageByAge(t *testing.T) {
+	now := time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC)
+
+	dir := &Dir{
+		File: &File{
+			Name:  "xxx",
+			Mtime: now,
+		},
+	}
+	dir.Files = fs.Files{
+		&File{Name: "hour", Size: 1, Usage: 4096, Mtime: now.Add(-time.Hour), Parent: dir},
+		&File{Name: "days", Size: 2, Usage: 4096, Mtime: now.Add(-3 * Day), Parent: dir},
+		&File{Name: "week", Size: 3, Usage: 4096, Mtime: now.Add(-7 * Day), Parent: dir},
+		&File{Name: "months", Size: 4, Usage: 8192, Mtime: now.Add(-60 * Day), Parent: dir},
+		&File{Name: "months2", Size: 5, Usage: 4096, Mtime: now.Add(-300 * Day), Parent: dir},
+		&File{Name: "years", Size: 6, Usage: 4096, Mtime: now.Add(-1000 * Day), Parent: dir},
+		&File{Name: "future", Size: 7, Usage: 4096, Mtime: now.Add(Day), Parent: dir},
+	}
+
+	buckets := GetUsageByAge(dir, now)
+
+	assert.Equal(t, 5, len(buckets))
+	assert.Equal(t, "< 1 day", buckets[0].Name)
+	assert.Equal(t, 2, buckets[0].FileCount)
+	assert.Equal(t, int64(8), buckets[0].Size)
+	assert.Equal(t, 1, buckets[1].FileCount)
+	assert.Equal(t, int64(2), buckets[1].Size)
+	assert.Equal(t, 1, buckets[2].FileCount)
+	assert.Equal(t, int64(3), buckets[2].Size)
+	assert.Equal(t, 2, buckets[3].FileCount)
+	assert.Equal(t, int64(12288), buckets[3].Usage)
+	assert.Equal(t, "> 1 year", buckets[4].Name)
+	assert.Equal(t, 1, buckets[4].FileCount)
+	assert.Equal(t, 365*Day, buckets[4].MinAge)
+}
+
+func TestIsOlderThan(t *testing.T) {
+	now := time.Date(2022, 10, 30, 12, 0, 0, 0, time.UTC)
+	file := &File{Name: "xxx", Mtime: now.Add(-10 * Day)}
+
+	assert.True(t, IsOlderThan(file, 10*Day, now))
+	assert.True(t, IsOlderThan(file, 5*Day, now))
+	assert.False(t, IsOlderThan(file, 11*Day, now))
+}
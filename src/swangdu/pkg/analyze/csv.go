@@ -0,0 +1,69 @@
+package analyze
+
+import (
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// CSVHeader contains names of columns written by EncodeCSV
+var CSVHeader = []string{
+	"path", "depth", "type", "asize", "dsize", "items", "mtime", "flag", "inode", "parent",
+}
+
+// EncodeCSV writes the tree as CSV with one row per item.
+// Rows are written while walking the tree, inode is empty if it is not known (e.g. on Windows).
+func EncodeCSV(writer io.Writer, item fs.Item) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write(CSVHeader); err != nil {
+		return err
+	}
+
+	path := item.GetPath()
+	if err := encodeCSV(w, item, path, filepath.Dir(path), 0); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func encodeCSV(w *csv.Writer, item fs.Item, path, parent string, depth int) error {
+	var mtime, flag, inode string
+	if !item.GetMtime().IsZero() {
+		mtime = item.GetMtime().Format(time.RFC3339)
+	}
+	if item.GetFlag() != 0 && item.GetFlag() != ' ' {
+		flag = string(item.GetFlag())
+	}
+	if item.GetInode() > 0 {
+		inode = strconv.FormatUint(item.GetInode(), 10)
+	}
+
+	err := w.Write([]string{
+		path,
+		strconv.Itoa(depth),
+		strings.ToLower(item.GetType()),
+		strconv.FormatInt(item.GetSize(), 10),
+		strconv.FormatInt(item.GetUsage(), 10),
+		strconv.Itoa(item.GetItemCount()),
+		mtime,
+		flag,
+		inode,
+		parent,
+	})
+	if err != nil || !item.IsDir() {
+		return err
+	}
+
+	for _, file := range item.GetFiles() {
+		if err := encodeCSV(w, file, filepath.Join(path, file.GetName()), path, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
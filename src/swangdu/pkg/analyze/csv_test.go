@@ -0,0 +1,77 @@
+package analyze
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name:  "test_dir",
+			Size:  10,
+			Usage: 18,
+			Mtime: time.Date(2021, 8, 19, 0, 40, 0, 0, time.UTC),
+		},
+		ItemCount: 3,
+		BasePath:  ".",
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "nested",
+			Size:   7,
+			Usage:  12,
+			Parent: dir,
+			Flag:   '!',
+			Ino:    42,
+		},
+		ItemCount: 2,
+	}
+	subdir.Files = fs.Files{
+		&File{
+			Name:   "file, \"quoted\"",
+			Size:   3,
+			Usage:  4,
+			Parent: subdir,
+			Flag:   'H',
+			Mli:    1234,
+		},
+	}
+	dir.Files = fs.Files{
+		subdir,
+		&File{Name: "link", Flag: '@', Parent: dir, Ino: 43},
+	}
+
+	var buff bytes.Buffer
+	err := EncodeCSV(&buff, dir)
+	assert.Nil(t, err)
+
+	assert.Equal(t, `path,depth,type,asize,dsize,items,mtime,flag,inode,parent
+test_dir,0,directory,10,18,3,2021-08-19T00:40:00Z,,,.
+test_dir/nested,1,directory,7,12,2,,!,42,test_dir
+"test_dir/nested/file, ""quoted""",2,file,3,4,1,,H,1234,test_dir/nested
+test_dir/link,1,other,0,0,1,,@,43,test_dir
+`, buff.String())
+}
+
+func TestEncodeCSVWritesInodeOfEveryItem(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	var buff bytes.Buffer
+	err := EncodeCSV(&buff, analyzeTestDir())
+	assert.Nil(t, err)
+
+	rows, err := csv.NewReader(&buff).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 6)
+	for _, row := range rows[1:] {
+		assert.NotEmpty(t, row[8], row[0])
+	}
+}
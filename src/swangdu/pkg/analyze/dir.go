@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strings"
+	"sync"
 
 	"github.com/ungtb10d/gdu/v5/internal/common"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
@@ -21,6 +23,18 @@ type ParallelAnalyzer struct {
 	doneChan        chan struct{}
 	wait            *WaitGroup
 	ignoreDir       common.ShouldDirBeIgnored
+	followSymlinks  bool
+	rootPath        string
+	visited         map[fs.MultiLinkedID]struct{}
+	symlinkedDirs   []*symlinkedDir
+	symlinksMutex   sync.Mutex
+}
+
+// symlinkedDir is symlink to directory waiting to be followed
+type symlinkedDir struct {
+	parent *Dir
+	path   string
+	info   os.FileInfo
 }
 
 // CreateAnalyzer returns Analyzer
@@ -56,6 +70,11 @@ func (a *ParallelAnalyzer) ResetProgress() {
 	a.wait = (&WaitGroup{}).Init()
 }
 
+// SetFollowSymlinks sets if symlinks should be resolved and their targets counted
+func (a *ParallelAnalyzer) SetFollowSymlinks(value bool) {
+	a.followSymlinks = value
+}
+
 // AnalyzeDir analyzes given path
 func (a *ParallelAnalyzer) AnalyzeDir(
 	path string, ignore common.ShouldDirBeIgnored, constGC bool,
@@ -66,6 +85,11 @@ func (a *ParallelAnalyzer) AnalyzeDir(
 	}
 
 	a.ignoreDir = ignore
+	a.visited = make(map[fs.MultiLinkedID]struct{})
+	a.symlinkedDirs = nil
+	if a.followSymlinks {
+		a.rootPath = resolvePath(path)
+	}
 
 	go a.updateProgress()
 	dir := a.processDir(path)
@@ -73,6 +97,8 @@ func (a *ParallelAnalyzer) AnalyzeDir(
 	dir.BasePath = filepath.Dir(path)
 	a.wait.Wait()
 
+	a.followSymlinkedDirs()
+
 	a.doneChan <- struct{}{} // finish updateProgress here
 	a.doneChan <- struct{}{} // and there
 	a.doneChan <- struct{}{} // and manageMemoryUsage
@@ -105,13 +131,20 @@ func (a *ParallelAnalyzer) processDir(path string) *Dir {
 		ItemCount: 1,
 		Files:     make(fs.Files, 0, len(files)),
 	}
+	if err != nil {
+		dir.Errors = append(dir.Errors, newScanError("", OpReadDir, err))
+	}
 	setDirPlatformSpecificAttrs(dir, path)
+	if a.followSymlinks {
+		a.markVisited(path)
+	}
 
 	for _, f := range files {
 		name := f.Name()
 		entryPath := filepath.Join(path, name)
 		if f.IsDir() {
 			if a.ignoreDir(name, entryPath) {
+				dir.AddFile(createExcludedDir(name, dir))
 				continue
 			}
 			dirCount++
@@ -128,15 +161,51 @@ func (a *ParallelAnalyzer) processDir(path string) *Dir {
 			info, err = f.Info()
 			if err != nil {
 				log.Print(err.Error())
+				dir.Errors = append(dir.Errors, newScanError(name, OpStat, err))
 				continue
 			}
+
+			var (
+				target     string
+				targetInfo os.FileInfo
+				terr       error
+			)
+			if info.Mode()&os.ModeSymlink != 0 {
+				target = readLink(entryPath)
+				targetInfo, terr = os.Stat(entryPath)
+			}
+
+			if a.followSymlinks && info.Mode()&os.ModeSymlink != 0 {
+				switch {
+				case terr != nil:
+					// broken symlink is counted as the symlink itself
+				case targetInfo.IsDir() && a.ignoreDir(name, entryPath):
+					// ignored directory is counted as the symlink itself
+				case targetInfo.IsDir():
+					a.addSymlinkedDir(dir, entryPath, info)
+					continue
+				case targetInfo.Mode().IsRegular() && !a.isInScannedTree(entryPath):
+					info = targetInfo
+				}
+			}
+
 			file = &File{
-				Name:   name,
-				Flag:   getFlag(info),
-				Size:   info.Size(),
-				Parent: dir,
+				Name:       name,
+				Flag:       getFlag(info),
+				Size:       info.Size(),
+				Target:     target,
+				Parent:     dir,
+				BrokenLink: target != "" && os.IsNotExist(terr),
 			}
 			setPlatformSpecificAttrs(file, info)
+			if a.followSymlinks && info.Mode().IsRegular() && file.Mli == 0 {
+				// file reachable through more symlinks (also to directories) is counted just once
+				// as hard links are
+				if id, ok := getID(info); ok {
+					file.Mli = id.Ino
+					file.Dev = id.Dev
+				}
+			}
 
 			totalSize += info.Size()
 
@@ -166,6 +235,101 @@ func (a *ParallelAnalyzer) processDir(path string) *Dir {
 	return dir
 }
 
+// followSymlinkedDirs analyzes targets of symlinked directories found in the tree.
+// It is done after the rest of the tree is analyzed so that directories already present in the tree
+// (and symlink cycles) are detected by the set of visited directories and not counted twice.
+func (a *ParallelAnalyzer) followSymlinkedDirs() {
+	for len(a.symlinkedDirs) > 0 {
+		pending := a.symlinkedDirs
+		a.symlinkedDirs = nil
+
+		for _, link := range pending {
+			target, err := os.Stat(link.path)
+			if err == nil && !a.isVisited(target) {
+				a.wait = (&WaitGroup{}).Init()
+				subdir := a.processDir(link.path)
+				a.wait.Wait()
+
+				if subdir.Flag == ' ' {
+					subdir.Flag = '@'
+				}
+				subdir.Target = readLink(link.path)
+				subdir.Parent = link.parent
+				link.parent.AddFile(subdir)
+				continue
+			}
+
+			file := &File{
+				Name:   filepath.Base(link.path),
+				Flag:   getFlag(link.info),
+				Size:   link.info.Size(),
+				Target: readLink(link.path),
+				Parent: link.parent,
+			}
+			file.BrokenLink = file.Target != "" && os.IsNotExist(err)
+			setPlatformSpecificAttrs(file, link.info)
+			link.parent.AddFile(file)
+		}
+	}
+}
+
+func (a *ParallelAnalyzer) addSymlinkedDir(parent *Dir, path string, info os.FileInfo) {
+	a.symlinksMutex.Lock()
+	defer a.symlinksMutex.Unlock()
+	a.symlinkedDirs = append(a.symlinkedDirs, &symlinkedDir{parent: parent, path: path, info: info})
+}
+
+func (a *ParallelAnalyzer) markVisited(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if id, ok := getID(info); ok {
+		a.symlinksMutex.Lock()
+		a.visited[id] = struct{}{}
+		a.symlinksMutex.Unlock()
+	}
+}
+
+// isVisited returns true if the directory was already analyzed or cannot be identified
+func (a *ParallelAnalyzer) isVisited(info os.FileInfo) bool {
+	id, ok := getID(info)
+	if !ok {
+		return true
+	}
+	a.symlinksMutex.Lock()
+	defer a.symlinksMutex.Unlock()
+	_, visited := a.visited[id]
+	return visited
+}
+
+// isInScannedTree returns true if target of the symlink lies in the analyzed directory
+func (a *ParallelAnalyzer) isInScannedTree(path string) bool {
+	target := resolvePath(path)
+	return target == a.rootPath ||
+		strings.HasPrefix(target, strings.TrimSuffix(a.rootPath, string(os.PathSeparator))+string(os.PathSeparator))
+}
+
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
+
+// readLink returns target of the symlink, empty if it cannot be read
+func readLink(path string) string {
+	target, err := os.Readlink(path)
+	if err != nil {
+		log.Print(err.Error())
+		return ""
+	}
+	return target
+}
+
 func (a *ParallelAnalyzer) updateProgress() {
 	for {
 		select {
@@ -5,8 +5,14 @@ package analyze
 
 import (
 	"os"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
 )
 
 func setPlatformSpecificAttrs(file *File, f os.FileInfo) {}
 
 func setDirPlatformSpecificAttrs(dir *Dir, path string) {}
+
+func getID(info os.FileInfo) (fs.MultiLinkedID, bool) {
+	return fs.MultiLinkedID{}, false
+}
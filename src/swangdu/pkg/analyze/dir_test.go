@@ -2,6 +2,7 @@ package analyze
 
 import (
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -77,6 +78,12 @@ func TestIgnoreDir(t *testing.T) {
 
 	assert.Equal(t, "test_dir", dir.Name)
 	assert.Equal(t, 1, dir.ItemCount)
+
+	// ignored directory is kept in the tree as excluded
+	assert.Len(t, dir.Files, 1)
+	assert.Equal(t, "nested", dir.Files[0].GetName())
+	assert.Equal(t, '<', dir.Files[0].GetFlag())
+	assert.Equal(t, "pattern", GetExcludedReason(dir.Files[0].GetFlag()))
 }
 
 func TestFlags(t *testing.T) {
@@ -106,6 +113,7 @@ func TestFlags(t *testing.T) {
 	assert.Equal(t, "file3", dir.Files[0].(*Dir).Files[1].GetName())
 	assert.Equal(t, int64(21), dir.Files[0].(*Dir).Files[1].GetSize())
 	assert.Equal(t, '@', dir.Files[0].(*Dir).Files[1].GetFlag())
+	assert.Equal(t, "test_dir/nested/file2", dir.Files[0].(*Dir).Files[1].GetTarget())
 
 	assert.Equal(t, 'e', dir.Files[1].GetFlag())
 }
@@ -157,6 +165,12 @@ func TestErr(t *testing.T) {
 
 	assert.Equal(t, "nested", dir.Files[0].GetName())
 	assert.Equal(t, '!', dir.Files[0].GetFlag())
+
+	errs := GetScanErrors(dir)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "test_dir/nested", errs[0].GetPath())
+	assert.Equal(t, OpReadDir, errs[0].Op)
+	assert.Equal(t, "permission denied", errs[0].Msg)
 }
 
 func BenchmarkAnalyzeDir(b *testing.B) {
@@ -172,3 +186,139 @@ func BenchmarkAnalyzeDir(b *testing.B) {
 	<-analyzer.GetDoneChan()
 	dir.UpdateStats(make(fs.HardLinkedItems))
 }
+
+func TestFollowSymlinks(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.MkdirAll("test_dir_outside", os.ModePerm)
+	assert.Nil(t, err)
+	defer func() {
+		err = os.RemoveAll("test_dir_outside")
+		assert.Nil(t, err)
+	}()
+	err = os.WriteFile("test_dir_outside/data", []byte("0123456789"), 0600)
+	assert.Nil(t, err)
+
+	outside, err := filepath.Abs("test_dir_outside")
+	assert.Nil(t, err)
+	nested, err := filepath.Abs("test_dir/nested")
+	assert.Nil(t, err)
+
+	assert.Nil(t, os.Symlink(outside, "test_dir/release"))
+	assert.Nil(t, os.Symlink(outside, "test_dir_outside/loop"))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "data"), "test_dir/data"))
+	assert.Nil(t, os.Symlink(filepath.Join(outside, "data"), "test_dir/data2"))
+	assert.Nil(t, os.Symlink(nested, "test_dir/inner"))
+	assert.Nil(t, os.Symlink(filepath.Join(nested, "file2"), "test_dir/file2"))
+	assert.Nil(t, os.Symlink("missing", "test_dir/broken"))
+
+	analyzer := CreateAnalyzer()
+	analyzer.SetFollowSymlinks(true)
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	items := make(map[string]fs.Item)
+	for _, item := range dir.Files {
+		items[item.GetName()] = item
+	}
+
+	// symlinked dir outside of the tree is followed
+	release := items["release"]
+	assert.True(t, release.IsDir())
+	assert.Equal(t, '@', release.GetFlag())
+	assert.Equal(t, 2, len(release.GetFiles()))
+	for _, item := range release.GetFiles() {
+		if item.GetName() == "loop" {
+			assert.False(t, item.IsDir()) // cycle is not followed
+			assert.Equal(t, '@', item.GetFlag())
+		} else {
+			assert.Equal(t, int64(10), item.GetSize())
+		}
+	}
+
+	// symlinked file outside of the tree is counted once,
+	// also when reached through symlinked directory
+	assert.Equal(t, int64(10), items["data"].GetSize())
+	assert.Equal(t, int64(10), items["data2"].GetSize())
+	counted := 0
+	for _, item := range []fs.Item{items["data"], items["data2"], release.GetFiles()[0], release.GetFiles()[1]} {
+		if item.GetName() != "loop" && item.GetFlag() != 'H' {
+			counted++
+		}
+	}
+	assert.Equal(t, 1, counted)
+
+	// targets inside the tree are not counted twice
+	assert.False(t, items["inner"].IsDir())
+	assert.Equal(t, '@', items["inner"].GetFlag())
+	assert.Equal(t, '@', items["file2"].GetFlag())
+	assert.Equal(t, '@', items["broken"].GetFlag())
+
+	// nested files, dirs, followed data files and sizes of symlinks not followed
+	expected := 7 + 4096*4 + 10 +
+		len(outside) + len(nested) + len(filepath.Join(nested, "file2")) + len("missing")
+	assert.Equal(t, int64(expected), dir.GetSize())
+}
+
+func TestFollowSymlinksToFileAndItsDir(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.MkdirAll("test_dir_outside", os.ModePerm)
+	assert.Nil(t, err)
+	defer func() {
+		err = os.RemoveAll("test_dir_outside")
+		assert.Nil(t, err)
+	}()
+	err = os.WriteFile("test_dir_outside/big", make([]byte, 1000), 0600)
+	assert.Nil(t, err)
+
+	assert.Nil(t, os.Symlink("../test_dir_outside/big", "test_dir/linkbig"))
+	assert.Nil(t, os.Symlink("../test_dir_outside", "test_dir/linkdir"))
+
+	analyzer := CreateAnalyzer()
+	analyzer.SetFollowSymlinks(true)
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	linkbig, _ := dir.Files.FindByName("linkbig")
+	linkdir, _ := dir.Files.FindByName("linkdir")
+	big := dir.Files[linkdir].GetFiles()[0]
+
+	assert.Equal(t, "big", big.GetName())
+	assert.Equal(t, int64(1000), big.GetSize())
+	assert.Equal(t, int64(1000), dir.Files[linkbig].GetSize())
+	assert.True(t, big.GetFlag() == 'H' || dir.Files[linkbig].GetFlag() == 'H')
+	assert.NotEqual(t, big.GetFlag(), dir.Files[linkbig].GetFlag())
+	assert.Equal(t, int64(7+4096*4+1000), dir.GetSize())
+}
+
+func TestFollowSymlinkToIgnoredDir(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	nested, err := filepath.Abs("test_dir/nested")
+	assert.Nil(t, err)
+	assert.Nil(t, os.Symlink(nested, "test_dir/ignored"))
+
+	analyzer := CreateAnalyzer()
+	analyzer.SetFollowSymlinks(true)
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(name, _ string) bool { return name == "ignored" }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	i, ok := dir.Files.FindByName("ignored")
+	assert.True(t, ok)
+	assert.False(t, dir.Files[i].IsDir())
+	assert.Equal(t, '@', dir.Files[i].GetFlag())
+	assert.Equal(t, nested, dir.Files[i].(*File).Target)
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"syscall"
 	"time"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
 )
 
 const devBSize = 512
@@ -15,10 +17,21 @@ func setPlatformSpecificAttrs(file *File, f os.FileInfo) {
 	switch stat := f.Sys().(type) {
 	case *syscall.Stat_t:
 		file.Usage = stat.Blocks * devBSize
+		if file.Flag == ' ' {
+			file.Flag = GetUsageFlag(file.Size, file.Usage)
+		}
 		file.Mtime = time.Unix(int64(stat.Mtimespec.Sec), int64(stat.Mtimespec.Nsec))
+		file.Atime = time.Unix(int64(stat.Atimespec.Sec), int64(stat.Atimespec.Nsec))
+		file.Ctime = time.Unix(int64(stat.Ctimespec.Sec), int64(stat.Ctimespec.Nsec))
+		file.UID = stat.Uid
+		file.GID = stat.Gid
+		file.Mode = uint32(stat.Mode)
+		file.Ino = uint64(stat.Ino)
 
 		if stat.Nlink > 1 {
 			file.Mli = stat.Ino
+			file.Dev = uint64(stat.Dev)
+			file.Nlink = uint32(stat.Nlink)
 		}
 	}
 }
@@ -30,4 +43,19 @@ func setDirPlatformSpecificAttrs(dir *Dir, path string) {
 	}
 
 	dir.Mtime = time.Unix(int64(stat.Mtimespec.Sec), int64(stat.Mtimespec.Nsec))
+	dir.Atime = time.Unix(int64(stat.Atimespec.Sec), int64(stat.Atimespec.Nsec))
+	dir.Ctime = time.Unix(int64(stat.Ctimespec.Sec), int64(stat.Ctimespec.Nsec))
+	dir.UID = stat.Uid
+	dir.GID = stat.Gid
+	dir.Mode = uint32(stat.Mode)
+	dir.Ino = uint64(stat.Ino)
+	dir.Dev = uint64(stat.Dev)
+}
+
+func getID(info os.FileInfo) (fs.MultiLinkedID, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fs.MultiLinkedID{}, false
+	}
+	return fs.MultiLinkedID{Dev: uint64(stat.Dev), Ino: uint64(stat.Ino)}, true
 }
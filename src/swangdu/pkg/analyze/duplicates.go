@@ -0,0 +1,215 @@
+package analyze
+
+import (
+	"crypto/sha256"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	log "github.com/sirupsen/logrus"
+)
+
+// partialHashSize is number of bytes read from the beginning of the files to quickly tell them apart
+const partialHashSize = 4096
+
+// DuplicateSet is group of files with identical content
+type DuplicateSet struct {
+	Size  int64
+	Usage int64
+	Files fs.Files
+}
+
+// Reclaimable returns disk usage which would be freed by keeping just one copy of the file
+func (s *DuplicateSet) Reclaimable() int64 {
+	return s.Usage * int64(len(s.Files)-1)
+}
+
+// DuplicateSets is list of duplicate sets sortable by reclaimable space
+type DuplicateSets []*DuplicateSet
+
+func (s DuplicateSets) Len() int      { return len(s) }
+func (s DuplicateSets) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s DuplicateSets) Less(i, j int) bool {
+	if s[i].Reclaimable() != s[j].Reclaimable() {
+		return s[i].Reclaimable() > s[j].Reclaimable()
+	}
+	return s[i].Files[0].GetPath() < s[j].Files[0].GetPath()
+}
+
+// FindDuplicates returns sets of files with identical content in the whole subtree of the item.
+// Files are grouped by size first, then by hash of their beginning and finally by hash of the whole content.
+// Hard links of the same file are considered to be one file.
+func FindDuplicates(item fs.Item) DuplicateSets {
+	bySize := make(map[int64]fs.Files)
+	linked := make(map[fs.MultiLinkedID]struct{})
+
+	walk(item, func(entry fs.Item) {
+		if entry.IsDir() || entry.GetFlag() == '@' || entry.GetFlag() == 'H' || entry.GetSize() == 0 {
+			return
+		}
+		if id := entry.GetMultiLinkedID(); id.Ino > 0 {
+			if _, ok := linked[id]; ok {
+				return
+			}
+			linked[id] = struct{}{}
+		}
+		bySize[entry.GetSize()] = append(bySize[entry.GetSize()], entry)
+	})
+
+	candidates := fs.Files{}
+	for _, files := range bySize {
+		if len(files) > 1 {
+			candidates = append(candidates, files...)
+		}
+	}
+
+	candidates = flattenGroups(groupByHash(candidates, partialHashSize))
+
+	sets := DuplicateSets{}
+	for _, files := range groupByHash(candidates, 0) {
+		sort.Slice(files, func(i, j int) bool {
+			return files[i].GetPath() < files[j].GetPath()
+		})
+		sets = append(sets, &DuplicateSet{
+			Size:  files[0].GetSize(),
+			Usage: files[0].GetUsage(),
+			Files: files,
+		})
+	}
+	sort.Sort(sets)
+	return sets
+}
+
+// ReplaceWithHardLink replaces the duplicate file with hard link to the original file
+func ReplaceWithHardLink(original fs.Item, duplicate fs.Item) error {
+	tmpPath := duplicate.GetPath() + ".gdu-link"
+	if err := os.Link(original.GetPath(), tmpPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, duplicate.GetPath()); err != nil {
+		if rerr := os.Remove(tmpPath); rerr != nil {
+			log.Print(rerr.Error())
+		}
+		return err
+	}
+
+	info, err := os.Lstat(original.GetPath())
+	if err != nil {
+		return err
+	}
+	link := &File{}
+	setPlatformSpecificAttrs(link, info)
+
+	if file, ok := original.(*File); ok {
+		file.Mli = link.Mli
+		file.Dev = link.Dev
+		file.Nlink = link.Nlink
+	}
+	if file, ok := duplicate.(*File); ok {
+		file.Mli = link.Mli
+		file.Dev = link.Dev
+		file.Nlink = link.Nlink
+		file.Flag = 'H'
+	}
+
+	cur := duplicate.GetParent().(*Dir)
+	for {
+		cur.Size -= duplicate.GetSize()
+		cur.Usage -= duplicate.GetUsage()
+
+		if cur.Parent == nil {
+			break
+		}
+		cur = cur.Parent.(*Dir)
+	}
+	return nil
+}
+
+// groupByHash groups files with the same size and the same hash of content
+// (limited to given number of bytes, whole content for zero limit).
+// Only groups with more than one file are returned.
+func groupByHash(files fs.Files, limit int64) []fs.Files {
+	type key struct {
+		size int64
+		hash string
+	}
+
+	hashes := hashFiles(files, limit)
+	groups := make(map[key]fs.Files)
+	keys := make([]key, 0)
+	for i, file := range files {
+		if hashes[i] == "" {
+			continue
+		}
+		k := key{size: file.GetSize(), hash: hashes[i]}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], file)
+	}
+
+	res := make([]fs.Files, 0)
+	for _, k := range keys {
+		if len(groups[k]) > 1 {
+			res = append(res, groups[k])
+		}
+	}
+	return res
+}
+
+// hashFiles computes hashes of the files in parallel.
+// Hash of file which cannot be read is empty.
+func hashFiles(files fs.Files, limit int64) []string {
+	hashes := make([]string, len(files))
+	wait := sync.WaitGroup{}
+
+	for i, file := range files {
+		concurrencyLimit <- struct{}{}
+		wait.Add(1)
+		go func(i int, path string) {
+			defer func() {
+				<-concurrencyLimit
+				wait.Done()
+			}()
+
+			hash, err := hashFile(path, limit)
+			if err != nil {
+				log.Print(err.Error())
+				return
+			}
+			hashes[i] = hash
+		}(i, file.GetPath())
+	}
+
+	wait.Wait()
+	return hashes
+}
+
+func hashFile(path string, limit int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if limit > 0 {
+		reader = io.LimitReader(file, limit)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return string(hash.Sum(nil)), nil
+}
+
+func flattenGroups(groups []fs.Files) fs.Files {
+	files := fs.Files{}
+	for _, group := range groups {
+		files = append(files, group...)
+	}
+	return files
+}
@@ -0,0 +1,113 @@
+package analyze
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicates(t)
+
+	dir := analyzeTestDir()
+	sets := FindDuplicates(dir)
+
+	assert.Equal(t, 1, len(sets))
+	assert.Equal(t, int64(5), sets[0].Size)
+	assert.Equal(t, 3, len(sets[0].Files))
+	assert.Equal(t, "test_dir/dup", sets[0].Files[0].GetPath())
+	assert.Equal(t, "test_dir/nested/dup", sets[0].Files[1].GetPath())
+	assert.Equal(t, "test_dir/nested/subnested/file", sets[0].Files[2].GetPath())
+	assert.Equal(t, 2*sets[0].Usage, sets[0].Reclaimable())
+}
+
+func TestFindDuplicatesWithoutDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	sets := FindDuplicates(analyzeTestDir())
+
+	assert.Equal(t, 0, len(sets))
+}
+
+func TestDuplicateSetsSorting(t *testing.T) {
+	dir := &Dir{File: &File{Name: "xxx"}, BasePath: "/"}
+	small := &DuplicateSet{Usage: 10, Files: fs.Files{
+		&File{Name: "a", Parent: dir}, &File{Name: "b", Parent: dir},
+	}}
+	big := &DuplicateSet{Usage: 5, Files: fs.Files{
+		&File{Name: "c", Parent: dir}, &File{Name: "d", Parent: dir}, &File{Name: "e", Parent: dir},
+	}}
+	same := &DuplicateSet{Usage: 10, Files: fs.Files{
+		&File{Name: "0", Parent: dir}, &File{Name: "1", Parent: dir},
+	}}
+
+	sets := DuplicateSets{small, big, same}
+	assert.True(t, sets.Less(0, 1))
+	assert.True(t, sets.Less(2, 0))
+	assert.False(t, sets.Less(1, 2))
+}
+
+func TestReplaceWithHardLink(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicates(t)
+
+	dir := analyzeTestDir()
+	sets := FindDuplicates(dir)
+	usage := dir.GetUsage()
+
+	original := sets[0].Files[2]
+	duplicate := sets[0].Files[0]
+	err := ReplaceWithHardLink(original, duplicate)
+	assert.Nil(t, err)
+
+	originalInfo, err := os.Stat(original.GetPath())
+	assert.Nil(t, err)
+	duplicateInfo, err := os.Stat(duplicate.GetPath())
+	assert.Nil(t, err)
+	assert.True(t, os.SameFile(originalInfo, duplicateInfo))
+
+	assert.Equal(t, 'H', duplicate.GetFlag())
+	assert.Equal(t, usage-duplicate.GetUsage(), dir.GetUsage())
+	assert.Equal(t, 2, len(FindDuplicates(analyzeTestDir())[0].Files))
+}
+
+func TestReplaceWithHardLinkFailing(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	dir := analyzeTestDir()
+	original := &File{Name: "missing", Parent: dir}
+
+	err := ReplaceWithHardLink(original, dir.Files[0].(*Dir).Files[0])
+	assert.NotNil(t, err)
+}
+
+func createDuplicates(t *testing.T) {
+	assert.Nil(t, os.WriteFile("test_dir/dup", []byte("hello"), 0600))
+	assert.Nil(t, os.WriteFile("test_dir/nested/dup", []byte("hello"), 0600))
+	assert.Nil(t, os.WriteFile("test_dir/same_size", []byte("world"), 0600))
+	assert.Nil(t, os.Link("test_dir/nested/file2", "test_dir/link"))
+
+	// same beginning, different content
+	prefix := strings.Repeat("a", partialHashSize)
+	assert.Nil(t, os.WriteFile("test_dir/big1", []byte(prefix+"x"), 0600))
+	assert.Nil(t, os.WriteFile("test_dir/big2", []byte(prefix+"y"), 0600))
+}
+
+func analyzeTestDir() *Dir {
+	analyzer := CreateAnalyzer()
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+	return dir
+}
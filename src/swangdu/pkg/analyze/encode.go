@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"io"
 	"strconv"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
 )
 
 // EncodeJSON writes JSON representation of dir
@@ -22,10 +24,24 @@ func (f *Dir) EncodeJSON(writer io.Writer, topLevel bool) error {
 		}
 	}
 
-	if !f.GetMtime().IsZero() {
-		buff = append(buff, []byte(`,"mtime":`)...)
-		buff = append(buff, []byte(strconv.FormatInt(f.GetMtime().Unix(), 10))...)
+	// size of the directory entry itself, the content is counted by the items
+	buff = append(buff, []byte(`,"asize":`+strconv.Itoa(dirOwnSize)+`,"dsize":`+strconv.Itoa(dirOwnSize))...)
+	addTimes(&buff, f)
+	if f.Target != "" {
+		buff = append(buff, []byte(`,"target":`)...)
+		if err := addString(&buff, f.Target); err != nil {
+			return err
+		}
+	}
+	addIno(&buff, f.File)
+	addDev(&buff, f.File, topLevel)
+	if f.Flag == '!' {
+		buff = append(buff, []byte(`,"read_error":true`)...)
+	}
+	if err := addErrors(&buff, f.Errors); err != nil {
+		return err
 	}
+	addExtendedInfo(&buff, f.File)
 
 	buff = append(buff, '}')
 	if f.Files.Len() > 0 {
@@ -71,17 +87,36 @@ func (f *File) EncodeJSON(writer io.Writer, topLevel bool) error {
 		buff = append(buff, []byte(`,"dsize":`)...)
 		buff = append(buff, []byte(strconv.FormatInt(f.GetUsage(), 10))...)
 	}
-	if !f.GetMtime().IsZero() {
-		buff = append(buff, []byte(`,"mtime":`)...)
-		buff = append(buff, []byte(strconv.FormatInt(f.GetMtime().Unix(), 10))...)
-	}
+	addTimes(&buff, f)
 
 	if f.Flag == '@' {
 		buff = append(buff, []byte(`,"notreg":true`)...)
 	}
-	if f.Flag == 'H' {
-		buff = append(buff, []byte(`,"ino":`+strconv.FormatUint(f.Mli, 10)+`,"hlnkc":true`)...)
+	if f.Target != "" {
+		buff = append(buff, []byte(`,"target":`)...)
+		if err := addString(&buff, f.Target); err != nil {
+			return err
+		}
+	}
+	if f.BrokenLink {
+		buff = append(buff, []byte(`,"broken":true`)...)
 	}
+	addIno(&buff, f)
+	addDev(&buff, f, topLevel)
+	// every link of the file is marked, not only the ones already counted
+	if f.Mli > 0 {
+		buff = append(buff, []byte(`,"hlnkc":true`)...)
+		if f.Nlink > 0 {
+			buff = append(buff, []byte(`,"nlink":`+strconv.FormatUint(uint64(f.Nlink), 10))...)
+		}
+	}
+	if f.Flag == '!' {
+		buff = append(buff, []byte(`,"read_error":true`)...)
+	}
+	if reason := GetExcludedReason(f.Flag); reason != "" {
+		buff = append(buff, []byte(`,"excluded":"`+reason+`"`)...)
+	}
+	addExtendedInfo(&buff, f)
 
 	buff = append(buff, '}')
 
@@ -91,6 +126,81 @@ func (f *File) EncodeJSON(writer io.Writer, topLevel bool) error {
 	return nil
 }
 
+// addIno writes inode number if it is known
+func addIno(buff *[]byte, f *File) {
+	if ino := f.GetInode(); ino > 0 {
+		*buff = append(*buff, []byte(`,"ino":`+strconv.FormatUint(ino, 10))...)
+	}
+}
+
+// addDev writes device number only if it differs from the device of the parent directory,
+// items without it are on the same device as their parent
+func addDev(buff *[]byte, f *File, topLevel bool) {
+	if f.Dev == 0 {
+		return
+	}
+	if parent, ok := f.Parent.(*Dir); ok && !topLevel && parent.Dev == f.Dev {
+		return
+	}
+	*buff = append(*buff, []byte(`,"dev":`+strconv.FormatUint(f.Dev, 10))...)
+}
+
+// addExtendedInfo writes owner and mode as in the extended mode of ncdu, if they are known
+func addExtendedInfo(buff *[]byte, f *File) {
+	if f.Mode == 0 {
+		return
+	}
+	*buff = append(*buff, []byte(`,"uid":`+strconv.FormatUint(uint64(f.UID), 10))...)
+	*buff = append(*buff, []byte(`,"gid":`+strconv.FormatUint(uint64(f.GID), 10))...)
+	*buff = append(*buff, []byte(`,"mode":`+strconv.FormatUint(uint64(f.Mode), 10))...)
+}
+
+func addTimes(buff *[]byte, f fs.Item) {
+	if !f.GetMtime().IsZero() {
+		*buff = append(*buff, []byte(`,"mtime":`)...)
+		*buff = append(*buff, []byte(strconv.FormatInt(f.GetMtime().Unix(), 10))...)
+	}
+	if !f.GetAtime().IsZero() {
+		*buff = append(*buff, []byte(`,"atime":`)...)
+		*buff = append(*buff, []byte(strconv.FormatInt(f.GetAtime().Unix(), 10))...)
+	}
+	if !f.GetCtime().IsZero() {
+		*buff = append(*buff, []byte(`,"ctime":`)...)
+		*buff = append(*buff, []byte(strconv.FormatInt(f.GetCtime().Unix(), 10))...)
+	}
+}
+
+// addErrors writes scan errors of the directory
+func addErrors(buff *[]byte, errs []*ScanError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	*buff = append(*buff, []byte(`,"errors":[`)...)
+	for i, err := range errs {
+		if i > 0 {
+			*buff = append(*buff, ',')
+		}
+		*buff = append(*buff, []byte(`{"name":`)...)
+		if err := addString(buff, err.Name); err != nil {
+			return err
+		}
+		*buff = append(*buff, []byte(`,"op":`)...)
+		if err := addString(buff, err.Op); err != nil {
+			return err
+		}
+		if err.Errno != 0 {
+			*buff = append(*buff, []byte(`,"errno":`+strconv.FormatUint(uint64(err.Errno), 10))...)
+		}
+		*buff = append(*buff, []byte(`,"msg":`)...)
+		if err := addString(buff, err.Msg); err != nil {
+			return err
+		}
+		*buff = append(*buff, '}')
+	}
+	*buff = append(*buff, ']')
+	return nil
+}
+
 func addString(buff *[]byte, val string) error {
 	b, err := json.Marshal(val)
 	if err != nil {
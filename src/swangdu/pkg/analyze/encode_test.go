@@ -2,6 +2,7 @@ package analyze
 
 import (
 	"bytes"
+	"syscall"
 	"testing"
 	"time"
 
@@ -34,6 +35,9 @@ func TestEncode(t *testing.T) {
 			Parent: dir,
 		},
 		ItemCount: 3,
+		Errors: []*ScanError{
+			{Name: "gone", Op: OpStat, Errno: syscall.ENOENT, Msg: "no such file or directory"},
+		},
 	}
 	file := &File{
 		Name:   "file2",
@@ -48,14 +52,41 @@ func TestEncode(t *testing.T) {
 		Parent: subdir,
 		Flag:   '@',
 		Mtime:  time.Date(2021, 8, 19, 0, 40, 0, 0, time.UTC),
+		Atime:  time.Date(2021, 8, 20, 0, 40, 0, 0, time.UTC),
+		Ctime:  time.Date(2021, 8, 21, 0, 40, 0, 0, time.UTC),
+	}
+	link := &File{
+		Name:   "link",
+		Flag:   '@',
+		Target: "../file2",
+		Parent: subdir,
+	}
+	broken := &File{
+		Name:       "broken",
+		Flag:       '@',
+		Target:     "missing",
+		Parent:     subdir,
+		BrokenLink: true,
 	}
 	file3 := &File{
-		Name: "file3",
-		Mli:  1234,
-		Flag: 'H',
+		Name:  "file3",
+		Mli:   1234,
+		Dev:   42,
+		Nlink: 2,
+		Flag:  'H',
+	}
+	excluded := &File{
+		Name:   "excluded",
+		Flag:   '>',
+		Parent: subdir,
+	}
+	unreadable := &File{
+		Name:   "unreadable",
+		Flag:   '!',
+		Parent: subdir,
 	}
 	dir.Files = fs.Files{subdir}
-	subdir.Files = fs.Files{file, file2, file3}
+	subdir.Files = fs.Files{file, file2, link, broken, file3, excluded, unreadable}
 
 	var buff bytes.Buffer
 	err := dir.EncodeJSON(&buff, true)
@@ -63,6 +94,66 @@ func TestEncode(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Contains(t, buff.String(), `"name":"nested"`)
 	assert.Contains(t, buff.String(), `"mtime":1629333600`)
-	assert.Contains(t, buff.String(), `"ino":1234`)
-	assert.Contains(t, buff.String(), `"hlnkc":true`)
+	assert.Contains(t, buff.String(), `"atime":1629420000`)
+	assert.Contains(t, buff.String(), `"ctime":1629506400`)
+	assert.Contains(t, buff.String(), `"ino":1234,"dev":42,"hlnkc":true,"nlink":2`)
+	assert.Contains(t, buff.String(), `{"name":"excluded","excluded":"otherfs"}`)
+	assert.Contains(t, buff.String(), `{"name":"unreadable","read_error":true}`)
+	assert.Contains(t, buff.String(), `"notreg":true,"target":"../file2"}`)
+	assert.Contains(t, buff.String(), `"notreg":true,"target":"missing","broken":true}`)
+	assert.Contains(
+		t,
+		buff.String(),
+		`"errors":[{"name":"gone","op":"stat","errno":2,"msg":"no such file or directory"}]`,
+	)
+	// only stat of an item failed, the directory itself was read
+	assert.Contains(t, buff.String(), `[{"name":"nested","asize":4096,"dsize":4096,"errors":`)
+}
+
+func TestEncodeExtendedInfo(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "test_dir",
+			Dev:  2049,
+			UID:  1000,
+			GID:  100,
+			Mode: 040755,
+		},
+		BasePath: "/home",
+	}
+	file := &File{
+		Name:   "file",
+		Size:   5,
+		Usage:  4096,
+		Mli:    12,
+		Dev:    2049,
+		UID:    0,
+		GID:    0,
+		Mode:   0100644,
+		Parent: dir,
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "mnt",
+			Dev:    2065,
+			Flag:   '!',
+			Parent: dir,
+		},
+	}
+	dir.Files = fs.Files{file, subdir}
+
+	var buff bytes.Buffer
+	err := dir.EncodeJSON(&buff, true)
+
+	assert.Nil(t, err)
+	assert.Contains(
+		t, buff.String(),
+		`[{"name":"/home/test_dir","asize":4096,"dsize":4096,"dev":2049,"uid":1000,"gid":100,"mode":16877}`,
+	)
+	// device of the parent is not repeated
+	assert.Contains(
+		t, buff.String(),
+		`{"name":"file","asize":5,"dsize":4096,"ino":12,"hlnkc":true,"uid":0,"gid":0,"mode":33188}`,
+	)
+	assert.Contains(t, buff.String(), `[{"name":"mnt","asize":4096,"dsize":4096,"dev":2065,"read_error":true}`)
 }
@@ -0,0 +1,118 @@
+package analyze
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// Operations which can fail during the analysis
+const (
+	OpReadDir = "readdir"
+	OpStat    = "stat"
+)
+
+// ScanError is error which occurred while reading the directory or one of its entries
+type ScanError struct {
+	// Name of the entry, empty if the directory itself could not be read
+	Name  string
+	Op    string
+	Errno syscall.Errno
+	Msg   string
+}
+
+// DirError is scan error together with the directory where it occurred
+type DirError struct {
+	*ScanError
+	Dir fs.Item
+}
+
+// GetPath returns path of the item which could not be read
+func (e *DirError) GetPath() string {
+	if e.Name == "" {
+		return e.Dir.GetPath()
+	}
+	return filepath.Join(e.Dir.GetPath(), e.Name)
+}
+
+// ErrorSummary is count of scan errors with the same operation and message
+type ErrorSummary struct {
+	Op    string
+	Msg   string
+	Count int
+}
+
+// String returns description of the errors, e.g. "42 directories unreadable: permission denied"
+func (s *ErrorSummary) String() string {
+	var what string
+	switch {
+	case s.Op == OpReadDir && s.Count == 1:
+		what = "directory unreadable"
+	case s.Op == OpReadDir:
+		what = "directories unreadable"
+	case s.Count == 1:
+		what = "entry inaccessible"
+	default:
+		what = "entries inaccessible"
+	}
+	return fmt.Sprintf("%d %s: %s", s.Count, what, s.Msg)
+}
+
+func newScanError(name, op string, err error) *ScanError {
+	scanErr := &ScanError{Name: name, Op: op, Msg: err.Error()}
+
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		scanErr.Msg = pathErr.Err.Error()
+	}
+	errors.As(err, &scanErr.Errno)
+	return scanErr
+}
+
+// GetScanErrors returns errors which occurred during the analysis of the whole subtree of the item, sorted by path
+func GetScanErrors(item fs.Item) []*DirError {
+	res := make([]*DirError, 0)
+	walk(item, func(entry fs.Item) {
+		dir, ok := entry.(*Dir)
+		if !ok {
+			return
+		}
+		for _, err := range dir.Errors {
+			res = append(res, &DirError{ScanError: err, Dir: dir})
+		}
+	})
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].GetPath() < res[j].GetPath()
+	})
+	return res
+}
+
+// SummarizeScanErrors counts errors by operation and message, the most frequent first
+func SummarizeScanErrors(errs []*DirError) []*ErrorSummary {
+	type key struct {
+		op  string
+		msg string
+	}
+
+	counts := make(map[key]*ErrorSummary)
+	res := make([]*ErrorSummary, 0)
+	for _, err := range errs {
+		k := key{op: err.Op, msg: err.Msg}
+		if _, ok := counts[k]; !ok {
+			counts[k] = &ErrorSummary{Op: err.Op, Msg: err.Msg}
+			res = append(res, counts[k])
+		}
+		counts[k].Count++
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Count > res[j].Count
+	})
+	return res
+}
@@ -0,0 +1,64 @@
+package analyze
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScanError(t *testing.T) {
+	err := newScanError("", OpReadDir, &os.PathError{Op: "open", Path: "/root", Err: syscall.EACCES})
+
+	assert.Equal(t, OpReadDir, err.Op)
+	assert.Equal(t, syscall.EACCES, err.Errno)
+	assert.Equal(t, "permission denied", err.Msg)
+
+	err = newScanError("file", OpStat, errors.New("unknown"))
+
+	assert.Equal(t, "file", err.Name)
+	assert.Equal(t, syscall.Errno(0), err.Errno)
+	assert.Equal(t, "unknown", err.Msg)
+}
+
+func TestGetScanErrors(t *testing.T) {
+	dir := &Dir{
+		File:     &File{Name: "root"},
+		BasePath: "/",
+		Errors: []*ScanError{
+			{Name: "gone", Op: OpStat, Msg: "no such file or directory"},
+		},
+	}
+	private := &Dir{
+		File:   &File{Name: "private", Parent: dir},
+		Errors: []*ScanError{{Op: OpReadDir, Msg: "permission denied"}},
+	}
+	secret := &Dir{
+		File:   &File{Name: "secret", Parent: dir},
+		Errors: []*ScanError{{Op: OpReadDir, Msg: "permission denied"}},
+	}
+	dir.AddFile(secret)
+	dir.AddFile(private)
+	dir.AddFile(&File{Name: "file", Parent: dir})
+
+	errs := GetScanErrors(dir)
+
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "/root/gone", errs[0].GetPath())
+	assert.Equal(t, "/root/private", errs[1].GetPath())
+	assert.Equal(t, "/root/secret", errs[2].GetPath())
+	assert.Equal(t, private, errs[1].Dir)
+
+	summary := SummarizeScanErrors(errs)
+
+	assert.Len(t, summary, 2)
+	assert.Equal(t, "2 directories unreadable: permission denied", summary[0].String())
+	assert.Equal(t, "1 entry inaccessible: no such file or directory", summary[1].String())
+}
+
+func TestErrorSummaryString(t *testing.T) {
+	assert.Equal(t, "1 directory unreadable: x", (&ErrorSummary{Op: OpReadDir, Msg: "x", Count: 1}).String())
+	assert.Equal(t, "3 entries inaccessible: x", (&ErrorSummary{Op: OpStat, Msg: "x", Count: 3}).String())
+}
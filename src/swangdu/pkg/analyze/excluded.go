@@ -0,0 +1,46 @@
+package analyze
+
+// flags of directories excluded from the analysis
+const (
+	flagExcluded = '<' // excluded by path, pattern or as hidden
+	flagOtherFs  = '>' // on other filesystem
+	flagKernFs   = '^' // kernel filesystem (e.g. /proc)
+	flagFirmlink = 'F' // macOS firmlink
+)
+
+// excludedReasons are reasons of the exclusion as used in the ncdu export format
+var excludedReasons = map[rune]string{
+	flagExcluded: "pattern",
+	flagOtherFs:  "otherfs",
+	flagKernFs:   "kernfs",
+	flagFirmlink: "frmlnk",
+}
+
+// GetExcludedReason returns reason why the item with given flag was excluded from the analysis,
+// empty string if the item was analyzed
+func GetExcludedReason(flag rune) string {
+	return excludedReasons[flag]
+}
+
+// GetExcludedFlag returns flag of the item excluded for given reason.
+// Unknown reasons are reported as exclusion by pattern.
+func GetExcludedFlag(reason string) rune {
+	for flag, r := range excludedReasons {
+		if r == reason {
+			return flag
+		}
+	}
+	if reason == "othfs" { // written by ncdu 1.x
+		return flagOtherFs
+	}
+	return flagExcluded
+}
+
+// createExcludedDir returns item standing for directory which was not analyzed
+func createExcludedDir(name string, parent *Dir) *File {
+	return &File{
+		Name:   name,
+		Flag:   flagExcluded,
+		Parent: parent,
+	}
+}
@@ -0,0 +1,22 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExcludedFlag(t *testing.T) {
+	assert.Equal(t, '<', GetExcludedFlag("pattern"))
+	assert.Equal(t, '>', GetExcludedFlag("otherfs"))
+	assert.Equal(t, '>', GetExcludedFlag("othfs"))
+	assert.Equal(t, '^', GetExcludedFlag("kernfs"))
+	assert.Equal(t, 'F', GetExcludedFlag("frmlnk"))
+	assert.Equal(t, '<', GetExcludedFlag("unknown"))
+}
+
+func TestGetExcludedReason(t *testing.T) {
+	assert.Equal(t, "otherfs", GetExcludedReason('>'))
+	assert.Equal(t, "", GetExcludedReason(' '))
+	assert.Equal(t, "", GetExcludedReason('H'))
+}
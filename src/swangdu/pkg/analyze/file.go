@@ -8,15 +8,29 @@ import (
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
 )
 
+// dirOwnSize is the size counted for the directory entry itself
+const dirOwnSize = 4096
+
 // File struct
 type File struct {
 	Mtime  time.Time
+	Atime  time.Time
+	Ctime  time.Time
 	Parent fs.Item
 	Name   string
+	Target string
 	Size   int64
 	Usage  int64
 	Mli    uint64
+	Ino    uint64
+	Dev    uint64
+	UID    uint32
+	GID    uint32
+	Mode   uint32
+	Nlink  uint32
 	Flag   rune
+	// BrokenLink is true for symlink whose target did not exist during the analysis
+	BrokenLink bool
 }
 
 // GetName returns name of dir
@@ -64,11 +78,38 @@ func (f *File) GetMtime() time.Time {
 	return f.Mtime
 }
 
+// GetAtime returns atime of the file
+func (f *File) GetAtime() time.Time {
+	return f.Atime
+}
+
+// GetCtime returns ctime of the file
+func (f *File) GetCtime() time.Time {
+	return f.Ctime
+}
+
+// GetTarget returns path the symlink points to, empty for other files
+func (f *File) GetTarget() string {
+	return f.Target
+}
+
+// GetUID returns id of the user owning the file
+func (f *File) GetUID() uint32 {
+	return f.UID
+}
+
+// GetGID returns id of the group owning the file
+func (f *File) GetGID() uint32 {
+	return f.GID
+}
+
 // GetType returns name type of item
 func (f *File) GetType() string {
 	switch f.Flag {
 	case '@':
 		return "Other"
+	case flagExcluded, flagOtherFs, flagKernFs, flagFirmlink:
+		return "Excluded"
 	}
 	return "File"
 }
@@ -83,15 +124,28 @@ func (f *File) GetMultiLinkedInode() uint64 {
 	return f.Mli
 }
 
+// GetInode returns inode number of the item, zero if it is not known
+func (f *File) GetInode() uint64 {
+	if f.Ino == 0 {
+		return f.Mli
+	}
+	return f.Ino
+}
+
+// GetMultiLinkedID returns device and inode number of multilinked file
+func (f *File) GetMultiLinkedID() fs.MultiLinkedID {
+	return fs.MultiLinkedID{Dev: f.Dev, Ino: f.Mli}
+}
+
 func (f *File) alreadyCounted(linkedItems fs.HardLinkedItems) bool {
-	mli := f.Mli
 	counted := false
-	if mli > 0 {
-		if _, ok := linkedItems[mli]; ok {
+	if f.Mli > 0 {
+		id := f.GetMultiLinkedID()
+		if _, ok := linkedItems[id]; ok {
 			f.Flag = 'H'
 			counted = true
 		}
-		linkedItems[mli] = append(linkedItems[mli], f)
+		linkedItems[id] = append(linkedItems[id], f)
 	}
 	return counted
 }
@@ -127,6 +181,7 @@ type Dir struct {
 	*File
 	BasePath  string
 	Files     fs.Files
+	Errors    []*ScanError
 	ItemCount int
 }
 
@@ -179,8 +234,8 @@ func (f *Dir) GetItemStats(linkedItems fs.HardLinkedItems) (int, int64, int64) {
 
 // UpdateStats recursively updates size and item count
 func (f *Dir) UpdateStats(linkedItems fs.HardLinkedItems) {
-	totalSize := int64(4096)
-	totalUsage := int64(4096)
+	totalSize := int64(dirOwnSize)
+	totalUsage := int64(dirOwnSize)
 	var itemCount int
 	for _, entry := range f.Files {
 		count, size, usage := entry.GetItemStats(linkedItems)
@@ -191,6 +246,12 @@ func (f *Dir) UpdateStats(linkedItems fs.HardLinkedItems) {
 		if entry.GetMtime().After(f.Mtime) {
 			f.Mtime = entry.GetMtime()
 		}
+		if entry.GetAtime().After(f.Atime) {
+			f.Atime = entry.GetAtime()
+		}
+		if entry.GetCtime().After(f.Ctime) {
+			f.Ctime = entry.GetCtime()
+		}
 
 		switch entry.GetFlag() {
 		case '!', '.':
@@ -364,12 +364,16 @@ func TestUpdateStats(t *testing.T) {
 		Name:   "yyy",
 		Size:   2,
 		Mtime:  time.Date(2021, 8, 19, 0, 41, 0, 0, time.UTC),
+		Atime:  time.Date(2021, 8, 19, 0, 45, 0, 0, time.UTC),
+		Ctime:  time.Date(2021, 8, 19, 0, 41, 0, 0, time.UTC),
 		Parent: &dir,
 	}
 	file2 := &File{
 		Name:   "zzz",
 		Size:   3,
 		Mtime:  time.Date(2021, 8, 19, 0, 42, 0, 0, time.UTC),
+		Atime:  time.Date(2021, 8, 19, 0, 43, 0, 0, time.UTC),
+		Ctime:  time.Date(2021, 8, 19, 0, 44, 0, 0, time.UTC),
 		Parent: &dir,
 	}
 	dir.Files = fs.Files{file, file2}
@@ -378,6 +382,8 @@ func TestUpdateStats(t *testing.T) {
 
 	assert.Equal(t, int64(4096+5), dir.Size)
 	assert.Equal(t, 42, dir.GetMtime().Minute())
+	assert.Equal(t, 45, dir.GetAtime().Minute())
+	assert.Equal(t, 44, dir.GetCtime().Minute())
 }
 
 func TestGetMultiLinkedInode(t *testing.T) {
@@ -389,6 +395,55 @@ func TestGetMultiLinkedInode(t *testing.T) {
 	assert.Equal(t, uint64(5), file.GetMultiLinkedInode())
 }
 
+func TestGetMultiLinkedID(t *testing.T) {
+	file := &File{
+		Name: "xxx",
+		Mli:  5,
+		Dev:  2,
+	}
+
+	assert.Equal(t, fs.MultiLinkedID{Dev: 2, Ino: 5}, file.GetMultiLinkedID())
+}
+
+func TestSameInodeOnDifferentDevices(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "xxx",
+		},
+	}
+	file := &File{
+		Name:   "aaa",
+		Size:   2,
+		Mli:    5,
+		Dev:    1,
+		Parent: dir,
+	}
+	file2 := &File{
+		Name:   "bbb",
+		Size:   2,
+		Mli:    5,
+		Dev:    2,
+		Parent: dir,
+	}
+	link := &File{
+		Name:   "ccc",
+		Size:   2,
+		Mli:    5,
+		Dev:    2,
+		Parent: dir,
+	}
+	dir.Files = fs.Files{file, file2, link}
+
+	linkedItems := make(fs.HardLinkedItems)
+	dir.UpdateStats(linkedItems)
+
+	assert.Equal(t, int64(4096+4), dir.Size)
+	assert.NotEqual(t, 'H', file2.GetFlag())
+	assert.Equal(t, 'H', link.GetFlag())
+	assert.Equal(t, 1, len(linkedItems[fs.MultiLinkedID{Dev: 1, Ino: 5}]))
+	assert.Equal(t, 2, len(linkedItems[fs.MultiLinkedID{Dev: 2, Ino: 5}]))
+}
+
 func TestGetPathWithoutLeadingSlash(t *testing.T) {
 	dir := &Dir{
 		File: &File{
@@ -0,0 +1,140 @@
+package analyze
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// NoExtension is used as extension name of files without extension
+const NoExtension = "(none)"
+
+// OtherCategory is category of files not matching any known extension
+const OtherCategory = "Other"
+
+// categories maps file extensions to coarse categories of files
+var categories = map[string]string{}
+
+func init() {
+	for category, extensions := range map[string][]string{
+		"Video": {
+			"3gp", "avi", "flv", "m2ts", "m4v", "mkv", "mov", "mp4",
+			"mpeg", "mpg", "mts", "ogv", "vob", "webm", "wmv",
+		},
+		"Images": {
+			"bmp", "cr2", "gif", "heic", "ico", "jpeg", "jpg", "nef",
+			"png", "psd", "svg", "tif", "tiff", "webp", "xcf",
+		},
+		"Archives": {
+			"7z", "bz2", "cab", "deb", "gz", "lz", "lz4", "lzma", "rar",
+			"rpm", "tar", "tbz2", "tgz", "txz", "xz", "z", "zip", "zst",
+		},
+		"VM images": {
+			"img", "iso", "ova", "ovf", "qcow", "qcow2", "vdi", "vhd",
+			"vhdx", "vmdk", "vmem", "vmsn", "vmss",
+		},
+		"Build artifacts": {
+			"a", "class", "dll", "dylib", "ear", "exe", "jar", "lib", "o",
+			"obj", "pch", "pdb", "pyc", "pyo", "rlib", "rmeta", "so",
+			"war", "whl",
+		},
+	} {
+		for _, ext := range extensions {
+			categories[ext] = category
+		}
+	}
+}
+
+// TypeUsage is disk usage aggregated for one file extension or category
+type TypeUsage struct {
+	Name      string
+	Size      int64
+	Usage     int64
+	FileCount int
+}
+
+// TypesUsage is a list of disk usages of file extensions or categories
+type TypesUsage []*TypeUsage
+
+func (f TypesUsage) Len() int      { return len(f) }
+func (f TypesUsage) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f TypesUsage) Less(i, j int) bool {
+	if f[i].Usage == f[j].Usage {
+		return f[i].Name < f[j].Name
+	}
+	return f[i].Usage > f[j].Usage
+}
+
+// TypesByApparentSize sorts file types by apparent size
+type TypesByApparentSize TypesUsage
+
+func (f TypesByApparentSize) Len() int      { return len(f) }
+func (f TypesByApparentSize) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f TypesByApparentSize) Less(i, j int) bool {
+	if f[i].Size == f[j].Size {
+		return f[i].Name < f[j].Name
+	}
+	return f[i].Size > f[j].Size
+}
+
+// GetExtension returns lowercased extension of the file name without the leading dot
+func GetExtension(name string) string {
+	ext := filepath.Ext(name)
+	if ext == "" || ext == name || ext == "." {
+		return NoExtension
+	}
+	return strings.ToLower(ext[1:])
+}
+
+// GetCategory returns coarse category of the file name, e.g. "Video" or "Archives"
+func GetCategory(name string) string {
+	if category, ok := categories[GetExtension(name)]; ok {
+		return category
+	}
+	return OtherCategory
+}
+
+// GetUsageByType returns usage of files in the whole subtree of the item
+// aggregated by category and by extension, sorted by disk usage
+func GetUsageByType(item fs.Item) (TypesUsage, TypesUsage) {
+	byCategory := make(map[string]*TypeUsage)
+	byExtension := make(map[string]*TypeUsage)
+
+	walk(item, func(entry fs.Item) {
+		if entry.IsDir() {
+			return
+		}
+		size, usage := ownSizes(entry)
+		ext := GetExtension(entry.GetName())
+		category, ok := categories[ext]
+		if !ok {
+			category = OtherCategory
+		}
+		addTypeUsage(byCategory, category, size, usage)
+		addTypeUsage(byExtension, ext, size, usage)
+	})
+
+	return sortedTypes(byCategory), sortedTypes(byExtension)
+}
+
+func addTypeUsage(types map[string]*TypeUsage, name string, size, usage int64) {
+	typeUsage, ok := types[name]
+	if !ok {
+		typeUsage = &TypeUsage{Name: name}
+		types[name] = typeUsage
+	}
+	typeUsage.Size += size
+	typeUsage.Usage += usage
+	typeUsage.FileCount++
+}
+
+func sortedTypes(types map[string]*TypeUsage) TypesUsage {
+	res := make(TypesUsage, 0, len(types))
+	for _, typeUsage := range types {
+		res = append(res, typeUsage)
+	}
+	sort.Sort(res)
+	return res
+}
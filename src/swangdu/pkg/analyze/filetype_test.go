@@ -0,0 +1,81 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestGetExtension(t *testing.T) {
+	assert.Equal(t, "gz", GetExtension("archive.tar.gz"))
+	assert.Equal(t, "mkv", GetExtension("Movie.MKV"))
+	assert.Equal(t, NoExtension, GetExtension("Makefile"))
+	assert.Equal(t, NoExtension, GetExtension(".bashrc"))
+	assert.Equal(t, NoExtension, GetExtension("file."))
+}
+
+func TestGetCategory(t *testing.T) {
+	assert.Equal(t, "Video", GetCategory("movie.mp4"))
+	assert.Equal(t, "Images", GetCategory("photo.JPG"))
+	assert.Equal(t, "Archives", GetCategory("backup.tar.zst"))
+	assert.Equal(t, "VM images", GetCategory("disk.qcow2"))
+	assert.Equal(t, "Build artifacts", GetCategory("main.o"))
+	assert.Equal(t, OtherCategory, GetCategory("README.md"))
+}
+
+func TestGetUsageByType(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "xxx",
+		},
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "yyy.mp4",
+			Parent: dir,
+		},
+	}
+	movie := &File{
+		Name:   "a.mp4",
+		Size:   100,
+		Usage:  4096,
+		Parent: subdir,
+	}
+	movie2 := &File{
+		Name:   "b.mkv",
+		Size:   200,
+		Usage:  8192,
+		Parent: subdir,
+	}
+	linked := &File{
+		Name:   "c.mkv",
+		Size:   200,
+		Usage:  8192,
+		Flag:   'H',
+		Parent: subdir,
+	}
+	readme := &File{
+		Name:   "README",
+		Size:   10,
+		Usage:  4096,
+		Parent: dir,
+	}
+	subdir.Files = fs.Files{movie, movie2, linked}
+	dir.Files = fs.Files{subdir, readme}
+
+	categories, extensions := GetUsageByType(dir)
+
+	assert.Equal(t, 2, len(categories))
+	assert.Equal(t, "Video", categories[0].Name)
+	assert.Equal(t, int64(12288), categories[0].Usage)
+	assert.Equal(t, int64(300), categories[0].Size)
+	assert.Equal(t, 3, categories[0].FileCount)
+	assert.Equal(t, OtherCategory, categories[1].Name)
+
+	assert.Equal(t, 3, len(extensions))
+	assert.Equal(t, "mkv", extensions[0].Name)
+	assert.Equal(t, 2, extensions[0].FileCount)
+	assert.Equal(t, NoExtension, extensions[1].Name)
+	assert.Equal(t, "mp4", extensions[2].Name)
+}
@@ -0,0 +1,36 @@
+package analyze
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// FindItem returns item on the path relative to the given directory, nil if there is no such item.
+// Components "." and ".." are resolved, but the path cannot lead above the top directory.
+func FindItem(dir fs.Item, path string) fs.Item {
+	item := dir
+	for _, name := range strings.Split(filepath.ToSlash(path), "/") {
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			if item.GetParent() == nil {
+				return nil
+			}
+			item = item.GetParent()
+			continue
+		}
+
+		if !item.IsDir() {
+			return nil
+		}
+		index, ok := item.GetFiles().FindByName(name)
+		if !ok {
+			return nil
+		}
+		item = item.GetFiles()[index]
+	}
+	return item
+}
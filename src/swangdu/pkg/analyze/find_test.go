@@ -0,0 +1,24 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindItem(t *testing.T) {
+	dir := &Dir{File: &File{Name: "root"}, BasePath: "/"}
+	nested := &Dir{File: &File{Name: "nested", Parent: dir}}
+	file := &File{Name: "file", Parent: nested}
+	nested.AddFile(file)
+	dir.AddFile(nested)
+
+	assert.Equal(t, file, FindItem(dir, "nested/file"))
+	assert.Equal(t, nested, FindItem(dir, "nested/"))
+	assert.Equal(t, dir, FindItem(dir, ""))
+	assert.Equal(t, dir, FindItem(nested, ".."))
+	assert.Equal(t, file, FindItem(nested, "./../nested/file"))
+	assert.Nil(t, FindItem(dir, ".."))
+	assert.Nil(t, FindItem(dir, "nested/missing"))
+	assert.Nil(t, FindItem(dir, "nested/file/deeper"))
+}
@@ -0,0 +1,77 @@
+package analyze
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+var foldedNameReplacer = strings.NewReplacer(";", "_", "\n", "_", "\r", "_")
+
+// EncodeFolded writes the tree as folded stacks used by flame graph tools,
+// one line per file in format `dir1;dir2;file <bytes>`.
+// Directories deeper than maxDepth (if greater than zero) are written as one line with their total size.
+// Size of the directory itself (not including its items) is written on line of the directory.
+// Hard links already counted elsewhere in the tree (flag H) are left out.
+func EncodeFolded(writer io.Writer, item fs.Item, apparentSize bool, maxDepth int) error {
+	w := bufio.NewWriter(writer)
+
+	size := func(item fs.Item) int64 {
+		if apparentSize {
+			return item.GetSize()
+		}
+		return item.GetUsage()
+	}
+
+	if err := encodeFolded(w, item, foldedName(item.GetPath()), 0, size, maxDepth); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func encodeFolded(
+	w *bufio.Writer, item fs.Item, stack string, depth int, size func(fs.Item) int64, maxDepth int,
+) error {
+	if !item.IsDir() || (maxDepth > 0 && depth >= maxDepth) {
+		return writeFoldedLine(w, stack, size(item))
+	}
+
+	// hard links counted already elsewhere are not included in size of the directory
+	files := make(fs.Files, 0, len(item.GetFiles()))
+	for _, file := range item.GetFiles() {
+		if file.GetFlag() != 'H' {
+			files = append(files, file)
+		}
+	}
+
+	own := size(item)
+	for _, file := range files {
+		own -= size(file)
+	}
+	if err := writeFoldedLine(w, stack, own); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		err := encodeFolded(w, file, stack+";"+foldedName(file.GetName()), depth+1, size, maxDepth)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFoldedLine(w *bufio.Writer, stack string, size int64) error {
+	if size <= 0 {
+		return nil
+	}
+	_, err := w.WriteString(stack + " " + strconv.FormatInt(size, 10) + "\n")
+	return err
+}
+
+func foldedName(name string) string {
+	return foldedNameReplacer.Replace(name)
+}
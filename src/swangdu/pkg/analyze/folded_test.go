@@ -0,0 +1,93 @@
+package analyze
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func createFoldedTestDir() *Dir {
+	dir := &Dir{
+		File: &File{
+			Name:  "test_dir",
+			Size:  30,
+			Usage: 40,
+		},
+		BasePath: ".",
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "nested",
+			Size:   20,
+			Usage:  28,
+			Parent: dir,
+		},
+	}
+	subdir.Files = fs.Files{
+		&File{Name: "file;1", Size: 15, Usage: 16, Parent: subdir},
+		&File{Name: "empty", Parent: subdir},
+	}
+	dir.Files = fs.Files{
+		subdir,
+		&File{Name: "file 2", Size: 6, Usage: 8, Parent: dir},
+	}
+	return dir
+}
+
+func TestEncodeFolded(t *testing.T) {
+	var buff bytes.Buffer
+	err := EncodeFolded(&buff, createFoldedTestDir(), false, 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, `test_dir 4
+test_dir;nested 12
+test_dir;nested;file_1 16
+test_dir;file 2 8
+`, buff.String())
+}
+
+func TestEncodeFoldedApparentSize(t *testing.T) {
+	var buff bytes.Buffer
+	err := EncodeFolded(&buff, createFoldedTestDir(), true, 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, `test_dir 4
+test_dir;nested 5
+test_dir;nested;file_1 15
+test_dir;file 2 6
+`, buff.String())
+}
+
+func TestEncodeFoldedMaxDepth(t *testing.T) {
+	var buff bytes.Buffer
+	err := EncodeFolded(&buff, createFoldedTestDir(), false, 1)
+	assert.Nil(t, err)
+
+	assert.Equal(t, `test_dir 4
+test_dir;nested 28
+test_dir;file 2 8
+`, buff.String())
+}
+
+func TestEncodeFoldedHardLinks(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "test_dir",
+			Flag: ' ',
+		},
+		BasePath: ".",
+	}
+	dir.Files = fs.Files{
+		&File{Name: "a", Size: 5, Usage: 8, Mli: 42, Flag: ' ', Parent: dir},
+		&File{Name: "b", Size: 5, Usage: 8, Mli: 42, Flag: ' ', Parent: dir},
+	}
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	var buff bytes.Buffer
+	err := EncodeFolded(&buff, dir, false, 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "test_dir 4096\ntest_dir;a 8\n", buff.String())
+}
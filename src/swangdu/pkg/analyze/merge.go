@@ -0,0 +1,201 @@
+package analyze
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// mergedRootName is name of the synthetic directory containing merged analyses
+const mergedRootName = "merged"
+
+// MergeDirs mounts analyzed directories into one virtual tree.
+// If keepPaths is true, every directory is placed at its original absolute path
+// and the missing intermediate directories are created,
+// otherwise all the directories are placed directly under synthetic root directory.
+// Items with colliding names get numeric suffix, e.g. "home (2)".
+// Device numbers used by more analyses are renumbered so that hard links are never
+// counted as one file across analyses (e.g. of different hosts).
+// Stats of the tree are not updated, UpdateStats needs to be called afterwards.
+func MergeDirs(dirs []*Dir, keepPaths bool) *Dir {
+	remapDevices(dirs)
+
+	m := &merger{created: make(map[*Dir]struct{})}
+	root := m.createDir(mergedRootName)
+	if keepPaths {
+		root.Name = "/"
+	}
+
+	for _, dir := range dirs {
+		parent := root
+		if keepPaths {
+			dirPath := filepath.ToSlash(dir.GetPath())
+			if dirPath == "/" {
+				// analysis of the whole filesystem becomes the root
+				dir.Name, dir.BasePath = "/", ""
+				for _, file := range root.Files {
+					m.add(dir, file)
+				}
+				root = dir
+				continue
+			}
+			parent = m.createParents(root, path.Dir(dirPath))
+		}
+		dir.BasePath = ""
+		m.add(parent, dir)
+	}
+	return root
+}
+
+// remapDevices gives device numbers already used by previous analysis new unused numbers
+func remapDevices(dirs []*Dir) {
+	used := make(map[uint64]struct{})
+	nextDev := uint64(math.MaxUint64)
+
+	for _, dir := range dirs {
+		devices := make(map[uint64]uint64)
+		walk(dir, func(item fs.Item) {
+			if file := getFile(item); file != nil {
+				devices[file.Dev] = file.Dev
+			}
+		})
+
+		colliding := make([]uint64, 0)
+		for dev := range devices {
+			if _, ok := used[dev]; ok {
+				colliding = append(colliding, dev)
+			}
+		}
+		for dev := range devices {
+			used[dev] = struct{}{}
+		}
+		if len(colliding) == 0 {
+			continue
+		}
+
+		for _, dev := range colliding {
+			for {
+				if _, ok := used[nextDev]; !ok {
+					break
+				}
+				nextDev--
+			}
+			devices[dev] = nextDev
+			used[nextDev] = struct{}{}
+		}
+
+		walk(dir, func(item fs.Item) {
+			if file := getFile(item); file != nil {
+				file.Dev = devices[file.Dev]
+			}
+		})
+	}
+}
+
+// merger remembers directories created only to hold merged items
+type merger struct {
+	created map[*Dir]struct{}
+}
+
+func (m *merger) createDir(name string) *Dir {
+	dir := &Dir{
+		File: &File{
+			Name: name,
+			Flag: ' ',
+		},
+	}
+	m.created[dir] = struct{}{}
+	return dir
+}
+
+// createParents returns directory for given absolute path, missing directories are created
+func (m *merger) createParents(root *Dir, dirPath string) *Dir {
+	dir := root
+	for _, name := range strings.Split(dirPath, "/") {
+		if name == "" {
+			continue
+		}
+		if i, ok := dir.Files.FindByName(name); ok {
+			if subdir, ok := dir.Files[i].(*Dir); ok {
+				dir = subdir
+				continue
+			}
+		}
+		subdir := m.createDir(name)
+		m.add(dir, subdir)
+		dir = subdir
+	}
+	return dir
+}
+
+// add adds item into parent directory.
+// Created directory is merged with directory of the same name,
+// other items with colliding names are renamed.
+func (m *merger) add(parent *Dir, item fs.Item) {
+	i, ok := parent.Files.FindByName(item.GetName())
+	if !ok {
+		item.SetParent(parent)
+		parent.AddFile(item)
+		return
+	}
+	existing := parent.Files[i]
+
+	if dir, ok := existing.(*Dir); ok && m.isCreated(dir) && item.IsDir() {
+		parent.Files = parent.Files.Remove(existing)
+		item.SetParent(parent)
+		parent.AddFile(item)
+		for _, file := range dir.Files {
+			m.add(item.(*Dir), file)
+		}
+		return
+	}
+	if dir, ok := item.(*Dir); ok && m.isCreated(dir) && existing.IsDir() {
+		for _, file := range dir.Files {
+			m.add(existing.(*Dir), file)
+		}
+		return
+	}
+
+	setName(item, uniqueName(parent, item.GetName()))
+	item.SetParent(parent)
+	parent.AddFile(item)
+}
+
+func (m *merger) isCreated(dir *Dir) bool {
+	_, ok := m.created[dir]
+	return ok
+}
+
+// uniqueName returns name with the lowest numeric suffix not used in the directory
+func uniqueName(dir *Dir, name string) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if _, ok := dir.Files.FindByName(candidate); !ok {
+			return candidate
+		}
+	}
+}
+
+// getFile returns file struct of the item
+func getFile(item fs.Item) *File {
+	switch f := item.(type) {
+	case *Dir:
+		return f.File
+	case *File:
+		return f
+	}
+	return nil
+}
+
+func setName(item fs.Item, name string) {
+	switch f := item.(type) {
+	case *Dir:
+		f.Name = name
+	case *File:
+		f.Name = name
+	}
+}
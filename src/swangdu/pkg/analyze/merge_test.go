@@ -0,0 +1,84 @@
+package analyze
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func createMergedDir(path string, files ...*File) *Dir {
+	basePath, name := filepath.Split(path)
+	dir := &Dir{File: &File{Name: name, Flag: ' '}, BasePath: basePath}
+	for _, file := range files {
+		file.Parent = dir
+		dir.AddFile(file)
+	}
+	return dir
+}
+
+func TestMergeDirsUnderSyntheticRoot(t *testing.T) {
+	home := createMergedDir("/home/xxx", &File{Name: "file", Size: 5, Usage: 8})
+	other := createMergedDir("/srv/xxx", &File{Name: "file", Size: 7, Usage: 8})
+
+	root := MergeDirs([]*Dir{home, other}, false)
+	root.UpdateStats(make(fs.HardLinkedItems))
+
+	assert.Equal(t, "merged", root.GetPath())
+	assert.Equal(t, "merged/xxx/file", home.Files[0].GetPath())
+	assert.Equal(t, "merged/xxx (2)/file", other.Files[0].GetPath())
+	assert.Equal(t, 5, root.ItemCount)
+	assert.Equal(t, int64(5+7+3*dirOwnSize), root.Size)
+}
+
+func TestMergeDirsAtOriginalPaths(t *testing.T) {
+	user := createMergedDir("/home/user", &File{Name: "file", Size: 5})
+	srv := createMergedDir("/srv", &File{Name: "file", Size: 7})
+	// parent of already merged directory replaces the created one
+	home := createMergedDir("/home", &File{Name: "other", Size: 3})
+	// collides with the existing file
+	dup := createMergedDir("/srv/file", &File{Name: "file", Size: 1})
+
+	root := MergeDirs([]*Dir{user, srv, home, dup}, true)
+	root.UpdateStats(make(fs.HardLinkedItems))
+
+	assert.Equal(t, "/", root.GetPath())
+	assert.Len(t, root.Files, 2)
+	assert.Equal(t, home, root.Files[1])
+	assert.Equal(t, "/home/user/file", user.Files[0].GetPath())
+	assert.Equal(t, "/home/other", home.Files[0].GetPath())
+	assert.Equal(t, "/srv/file (2)/file", dup.Files[0].GetPath())
+	assert.Equal(t, int64(5+7+3+1+5*dirOwnSize), root.Size)
+}
+
+func TestMergeDirsWithRoot(t *testing.T) {
+	user := createMergedDir("/home/user", &File{Name: "file", Size: 5})
+	all := createMergedDir("/", &File{Name: "file", Size: 7})
+
+	root := MergeDirs([]*Dir{user, all}, true)
+
+	assert.Equal(t, all, root)
+	assert.Equal(t, "/", root.GetPath())
+	assert.Equal(t, "/home/user/file", user.Files[0].GetPath())
+	assert.Equal(t, "/file", all.Files[0].GetPath())
+}
+
+func TestMergeDirsCountsHardLinksOfEveryAnalysis(t *testing.T) {
+	first := createMergedDir("/home/xxx",
+		&File{Name: "link", Size: 5, Usage: 8, Mli: 42, Dev: 1, Flag: ' '},
+		&File{Name: "other", Size: 5, Usage: 8, Mli: 42, Dev: 1, Flag: ' '},
+	)
+	// the same device and inode number on another host
+	second := createMergedDir("/home/yyy", &File{Name: "link", Size: 5, Usage: 8, Mli: 42, Dev: 1, Flag: ' '})
+
+	root := MergeDirs([]*Dir{first, second}, true)
+	root.UpdateStats(make(fs.HardLinkedItems))
+
+	assert.Equal(t, ' ', first.Files[0].GetFlag())
+	assert.Equal(t, 'H', first.Files[1].GetFlag())
+	assert.Equal(t, ' ', second.Files[0].GetFlag())
+	assert.Equal(t, uint64(1), first.Files[0].(*File).Dev)
+	assert.NotEqual(t, uint64(1), second.Files[0].(*File).Dev)
+	assert.Equal(t, int64(2*8+4*dirOwnSize), root.Usage)
+}
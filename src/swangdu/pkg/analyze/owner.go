@@ -0,0 +1,103 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// UnknownOwner is name shown for owner of items without owner information
+const UnknownOwner = "unknown"
+
+// OwnerUsage is disk usage aggregated for one user or group.
+// Unknown is true for items without owner information (e.g. imported from export without extended info).
+type OwnerUsage struct {
+	ID        uint32
+	Unknown   bool
+	Size      int64
+	Usage     int64
+	ItemCount int
+}
+
+// OwnersUsage is a list of disk usages of users or groups
+type OwnersUsage []*OwnerUsage
+
+func (f OwnersUsage) Len() int      { return len(f) }
+func (f OwnersUsage) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f OwnersUsage) Less(i, j int) bool {
+	if f[i].Usage == f[j].Usage {
+		return f[i].ID < f[j].ID
+	}
+	return f[i].Usage > f[j].Usage
+}
+
+// OwnersByApparentSize sorts owners by apparent size
+type OwnersByApparentSize OwnersUsage
+
+func (f OwnersByApparentSize) Len() int      { return len(f) }
+func (f OwnersByApparentSize) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f OwnersByApparentSize) Less(i, j int) bool {
+	if f[i].Size == f[j].Size {
+		return f[i].ID < f[j].ID
+	}
+	return f[i].Size > f[j].Size
+}
+
+// GetUsageByOwner returns usage of the whole subtree of the item
+// aggregated by owning user and by owning group, sorted by disk usage
+func GetUsageByOwner(item fs.Item) (OwnersUsage, OwnersUsage) {
+	users := make(map[uint32]*OwnerUsage)
+	groups := make(map[uint32]*OwnerUsage)
+	unknownUser := &OwnerUsage{Unknown: true}
+	unknownGroup := &OwnerUsage{Unknown: true}
+
+	walk(item, func(entry fs.Item) {
+		if GetExcludedReason(entry.GetFlag()) != "" {
+			return
+		}
+		size, usage := ownSizes(entry)
+		if !HasOwner(entry) {
+			addUsage(unknownUser, size, usage)
+			addUsage(unknownGroup, size, usage)
+			return
+		}
+		addUsage(getOwner(users, entry.GetUID()), size, usage)
+		addUsage(getOwner(groups, entry.GetGID()), size, usage)
+	})
+
+	return sortedOwners(users, unknownUser), sortedOwners(groups, unknownGroup)
+}
+
+// HasOwner returns true if owner of the item is known.
+// Mode is not set for items without extended info, so their UID and GID are not valid either.
+func HasOwner(item fs.Item) bool {
+	file := getFile(item)
+	return file != nil && file.Mode != 0 && GetExcludedReason(file.Flag) == ""
+}
+
+func getOwner(owners map[uint32]*OwnerUsage, id uint32) *OwnerUsage {
+	owner, ok := owners[id]
+	if !ok {
+		owner = &OwnerUsage{ID: id}
+		owners[id] = owner
+	}
+	return owner
+}
+
+func addUsage(owner *OwnerUsage, size, usage int64) {
+	owner.Size += size
+	owner.Usage += usage
+	owner.ItemCount++
+}
+
+func sortedOwners(owners map[uint32]*OwnerUsage, unknown *OwnerUsage) OwnersUsage {
+	res := make(OwnersUsage, 0, len(owners)+1)
+	for _, owner := range owners {
+		res = append(res, owner)
+	}
+	if unknown.ItemCount > 0 {
+		res = append(res, unknown)
+	}
+	sort.Sort(res)
+	return res
+}
@@ -0,0 +1,102 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestGetUsageByOwner(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "xxx",
+			UID:  1000,
+			GID:  100,
+			Mode: 040755,
+		},
+	}
+	file := &File{
+		Name:   "yyy",
+		Size:   2,
+		Usage:  4096,
+		UID:    1000,
+		GID:    100,
+		Mode:   0100644,
+		Parent: dir,
+	}
+	file2 := &File{
+		Name:   "zzz",
+		Size:   3,
+		Usage:  4096,
+		UID:    0,
+		GID:    100,
+		Mode:   0100644,
+		Parent: dir,
+	}
+	linked := &File{
+		Name:   "aaa",
+		Size:   3,
+		Usage:  8192,
+		UID:    0,
+		GID:    0,
+		Mode:   0100644,
+		Flag:   'H',
+		Parent: dir,
+	}
+	dir.Files = fs.Files{file, file2, linked}
+
+	users, groups := GetUsageByOwner(dir)
+
+	assert.Equal(t, 2, len(users))
+	assert.Equal(t, uint32(1000), users[0].ID)
+	assert.Equal(t, int64(8192), users[0].Usage)
+	assert.Equal(t, int64(4098), users[0].Size)
+	assert.Equal(t, 2, users[0].ItemCount)
+	assert.Equal(t, uint32(0), users[1].ID)
+	assert.Equal(t, int64(4096), users[1].Usage)
+	assert.Equal(t, 2, users[1].ItemCount)
+
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, uint32(100), groups[0].ID)
+	assert.Equal(t, int64(12288), groups[0].Usage)
+	assert.Equal(t, 3, groups[0].ItemCount)
+	assert.Equal(t, uint32(0), groups[1].ID)
+	assert.Equal(t, int64(0), groups[1].Usage)
+}
+
+func TestGetUsageByOwnerWithUnknownOwner(t *testing.T) {
+	dir := &Dir{
+		File: &File{
+			Name: "xxx",
+			UID:  1000,
+			GID:  100,
+			Mode: 040755,
+		},
+	}
+	file := &File{
+		Name:   "yyy",
+		Size:   2,
+		Usage:  100,
+		Parent: dir,
+	}
+	excluded := createExcludedDir("zzz", dir)
+	dir.Files = fs.Files{file, excluded}
+
+	users, groups := GetUsageByOwner(dir)
+
+	assert.Equal(t, 2, len(users))
+	assert.Equal(t, uint32(1000), users[0].ID)
+	assert.False(t, users[0].Unknown)
+	assert.Equal(t, 1, users[0].ItemCount)
+	assert.True(t, users[1].Unknown)
+	assert.Equal(t, int64(100), users[1].Usage)
+	assert.Equal(t, 1, users[1].ItemCount)
+
+	assert.Equal(t, 2, len(groups))
+	assert.True(t, groups[1].Unknown)
+
+	assert.True(t, HasOwner(dir))
+	assert.False(t, HasOwner(file))
+	assert.False(t, HasOwner(excluded))
+}
@@ -0,0 +1,71 @@
+package analyze
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// RegexpPrefix marks name pattern as regular expression
+const RegexpPrefix = "re:"
+
+// CompileNamePattern returns function matching item names against the pattern.
+// Pattern prefixed with "re:" is regular expression, pattern containing *, ? or [ is shell glob
+// and any other pattern matches names containing it, case insensitive unless it contains uppercase letters.
+func CompileNamePattern(pattern string) (func(name string) bool, error) {
+	switch {
+	case strings.HasPrefix(pattern, RegexpPrefix):
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, RegexpPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	case strings.ContainsAny(pattern, "*?["):
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+		return func(name string) bool {
+			matched, _ := filepath.Match(pattern, name)
+			return matched
+		}, nil
+	case strings.ToLower(pattern) != pattern:
+		return func(name string) bool {
+			return strings.Contains(name, pattern)
+		}, nil
+	default:
+		return func(name string) bool {
+			return strings.Contains(strings.ToLower(name), pattern)
+		}, nil
+	}
+}
+
+// SearchItems returns items in the whole subtree of the item with name matching the function,
+// the largest first. Content of matching directories is not searched,
+// so that the sizes of the found items can be summed up.
+func SearchItems(item fs.Item, match func(name string) bool, apparentSize bool) fs.Files {
+	files := fs.Files{}
+
+	var search func(dir fs.Item)
+	search = func(dir fs.Item) {
+		for _, entry := range dir.GetFiles() {
+			if match(entry.GetName()) {
+				files = append(files, entry)
+				continue
+			}
+			if entry.IsDir() {
+				search(entry)
+			}
+		}
+	}
+	search(item)
+
+	if apparentSize {
+		sort.Sort(fs.ByApparentSize(files))
+	} else {
+		sort.Sort(files)
+	}
+	return files
+}
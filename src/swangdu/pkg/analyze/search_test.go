@@ -0,0 +1,56 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileNamePattern(t *testing.T) {
+	match, err := CompileNamePattern("core")
+	assert.Nil(t, err)
+	assert.True(t, match("Core.1234"))
+	assert.False(t, match("dump.hprof"))
+
+	match, err = CompileNamePattern("Core")
+	assert.Nil(t, err)
+	assert.True(t, match("Core.1234"))
+	assert.False(t, match("core.1234"))
+
+	match, err = CompileNamePattern("*.hprof")
+	assert.Nil(t, err)
+	assert.True(t, match("java_pid1.hprof"))
+	assert.False(t, match("java_pid1.hprof.gz"))
+
+	match, err = CompileNamePattern(`re:^core\.[0-9]+$`)
+	assert.Nil(t, err)
+	assert.True(t, match("core.1234"))
+	assert.False(t, match("core.txt"))
+}
+
+func TestCompileWrongNamePattern(t *testing.T) {
+	_, err := CompileNamePattern("re:(")
+	assert.NotNil(t, err)
+
+	_, err = CompileNamePattern("[")
+	assert.NotNil(t, err)
+}
+
+func TestSearchItems(t *testing.T) {
+	dir := &Dir{File: &File{Name: "root"}, BasePath: "/"}
+	cores := &Dir{File: &File{Name: "cores", Usage: 300, Parent: dir}}
+	cores.AddFile(&File{Name: "core.1", Usage: 200, Parent: cores})
+	app := &Dir{File: &File{Name: "app", Usage: 100, Parent: dir}}
+	app.AddFile(&File{Name: "core.2", Usage: 50, Parent: app})
+	app.AddFile(&File{Name: "main.go", Usage: 10, Parent: app})
+	dir.Files = fs.Files{app, cores}
+
+	match, err := CompileNamePattern("core")
+	assert.Nil(t, err)
+	files := SearchItems(dir, match, false)
+
+	assert.Len(t, files, 2)
+	assert.Equal(t, "/root/cores", files[0].GetPath()) // content of matching dir is not searched
+	assert.Equal(t, "/root/app/core.2", files[1].GetPath())
+}
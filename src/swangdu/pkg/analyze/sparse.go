@@ -0,0 +1,74 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+const (
+	// sparseRatio is usage to size ratio below which the file is considered sparse (or compressed)
+	sparseRatio = 0.5
+	// inflatedRatio is usage to size ratio above which the file is considered inflated
+	inflatedRatio = 2.0
+	// minUsageDifference is minimal difference between size and usage of flagged file,
+	// so that small files are not flagged just because of rounding to blocks
+	minUsageDifference = 64 * 1024
+)
+
+// GetUsageRatio returns ratio of disk usage to apparent size of the item, 1 for empty items
+func GetUsageRatio(item fs.Item) float64 {
+	if item.GetSize() == 0 {
+		return 1
+	}
+	return float64(item.GetUsage()) / float64(item.GetSize())
+}
+
+// GetUsageFlag returns 'S' for file using much less disk space than its size (sparse or compressed),
+// 'I' for file using much more disk space than its size (inflated) and ' ' otherwise
+func GetUsageFlag(size int64, usage int64) rune {
+	switch {
+	case size-usage >= minUsageDifference && float64(usage) < sparseRatio*float64(size):
+		return 'S'
+	case usage-size >= minUsageDifference && float64(usage) > inflatedRatio*float64(size):
+		return 'I'
+	default:
+		return ' '
+	}
+}
+
+// GetSparseFiles returns at most limit files in the whole subtree of the item
+// which are sparse or compressed, the ones saving the most disk space first
+func GetSparseFiles(item fs.Item, limit int) fs.Files {
+	return getFilesByUsageFlag(item, limit, 'S', func(f fs.Item) int64 {
+		return f.GetSize() - f.GetUsage()
+	})
+}
+
+// GetInflatedFiles returns at most limit files in the whole subtree of the item
+// which use much more disk space than their size, the ones wasting the most disk space first
+func GetInflatedFiles(item fs.Item, limit int) fs.Files {
+	return getFilesByUsageFlag(item, limit, 'I', func(f fs.Item) int64 {
+		return f.GetUsage() - f.GetSize()
+	})
+}
+
+func getFilesByUsageFlag(item fs.Item, limit int, flag rune, difference func(fs.Item) int64) fs.Files {
+	files := fs.Files{}
+	walk(item, func(entry fs.Item) {
+		if entry.IsDir() || entry.GetFlag() == 'H' || entry.GetFlag() == '@' {
+			return
+		}
+		if GetUsageFlag(entry.GetSize(), entry.GetUsage()) == flag {
+			files = append(files, entry)
+		}
+	})
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return difference(files[i]) > difference(files[j])
+	})
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
@@ -0,0 +1,121 @@
+package analyze
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestGetUsageFlag(t *testing.T) {
+	assert.Equal(t, ' ', GetUsageFlag(0, 0))
+	assert.Equal(t, ' ', GetUsageFlag(5, 4096))
+	assert.Equal(t, ' ', GetUsageFlag(4096, 0))
+	assert.Equal(t, ' ', GetUsageFlag(1<<20, 1<<20))
+	assert.Equal(t, 'S', GetUsageFlag(1<<20, 0))
+	assert.Equal(t, 'S', GetUsageFlag(1<<30, 1<<20))
+	assert.Equal(t, ' ', GetUsageFlag(1<<30, 1<<29+1))
+	assert.Equal(t, 'I', GetUsageFlag(5, 128*1024))
+	assert.Equal(t, ' ', GetUsageFlag(1<<20, 1<<21))
+}
+
+func TestGetUsageRatio(t *testing.T) {
+	assert.Equal(t, 1.0, GetUsageRatio(&File{}))
+	assert.Equal(t, 0.5, GetUsageRatio(&File{Size: 10, Usage: 5}))
+	assert.Equal(t, 2.0, GetUsageRatio(&File{Size: 10, Usage: 20}))
+}
+
+func TestGetSparseFiles(t *testing.T) {
+	dir := getFilesForUsageRatio()
+
+	files := GetSparseFiles(dir, 10)
+
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, "vm.img", files[0].GetName())
+	assert.Equal(t, "compressed", files[1].GetName())
+
+	assert.Equal(t, 1, len(GetSparseFiles(dir, 1)))
+}
+
+func TestGetInflatedFiles(t *testing.T) {
+	dir := getFilesForUsageRatio()
+
+	files := GetInflatedFiles(dir, 10)
+
+	assert.Equal(t, 1, len(files))
+	assert.Equal(t, "tiny", files[0].GetName())
+}
+
+func TestSparseFileFlag(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	file, err := os.Create("test_dir/sparse")
+	assert.Nil(t, err)
+	assert.Nil(t, file.Truncate(1<<24))
+	assert.Nil(t, file.Close())
+
+	analyzer := CreateAnalyzer()
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	for _, item := range dir.Files {
+		if item.GetName() == "sparse" {
+			assert.Equal(t, 'S', item.GetFlag())
+		}
+	}
+}
+
+func getFilesForUsageRatio() *Dir {
+	dir := &Dir{
+		File: &File{
+			Name: "root",
+		},
+		BasePath: "/",
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "images",
+			Parent: dir,
+		},
+	}
+	vm := &File{
+		Name:   "vm.img",
+		Size:   1 << 30,
+		Usage:  1 << 20,
+		Parent: subdir,
+	}
+	compressed := &File{
+		Name:   "compressed",
+		Size:   1 << 20,
+		Usage:  1 << 18,
+		Parent: dir,
+	}
+	linked := &File{
+		Name:   "compressed-link",
+		Size:   1 << 20,
+		Usage:  1 << 18,
+		Flag:   'H',
+		Parent: dir,
+	}
+	tiny := &File{
+		Name:   "tiny",
+		Size:   10,
+		Usage:  1 << 20,
+		Parent: dir,
+	}
+	normal := &File{
+		Name:   "normal",
+		Size:   1 << 20,
+		Usage:  1 << 20,
+		Parent: dir,
+	}
+	subdir.Files = fs.Files{vm}
+	dir.Files = fs.Files{subdir, compressed, linked, tiny, normal}
+	return dir
+}
@@ -0,0 +1,28 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// GetBrokenSymlinks returns symlinks in the whole subtree of the item whose target does not exist, sorted by path
+func GetBrokenSymlinks(item fs.Item) fs.Files {
+	files := fs.Files{}
+	walk(item, func(entry fs.Item) {
+		if !entry.IsDir() && IsBrokenSymlink(entry) {
+			files = append(files, entry)
+		}
+	})
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GetPath() < files[j].GetPath()
+	})
+	return files
+}
+
+// IsBrokenSymlink returns true if the item is symlink whose target could not be reached during the analysis
+func IsBrokenSymlink(item fs.Item) bool {
+	file, ok := item.(*File)
+	return ok && file.BrokenLink
+}
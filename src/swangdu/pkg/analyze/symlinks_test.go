@@ -0,0 +1,69 @@
+package analyze
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBrokenSymlinks(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.Symlink("missing", "test_dir/nested/dangling")
+	assert.Nil(t, err)
+	err = os.Symlink("file2", "test_dir/nested/valid")
+	assert.Nil(t, err)
+	err = os.Symlink("missing", "test_dir/broken")
+	assert.Nil(t, err)
+
+	analyzer := CreateAnalyzer()
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	links := GetBrokenSymlinks(dir)
+
+	assert.Len(t, links, 2)
+	assert.Equal(t, "test_dir/broken", links[0].GetPath())
+	assert.Equal(t, "test_dir/nested/dangling", links[1].GetPath())
+	assert.Equal(t, "missing", links[1].GetTarget())
+}
+
+func TestIsBrokenSymlink(t *testing.T) {
+	dir := &Dir{File: &File{Name: "nested"}, BasePath: "test_dir"}
+	valid := &File{Name: "valid", Target: "file2", Parent: dir}
+	broken := &File{Name: "removed", Target: "file2", Parent: dir, BrokenLink: true}
+	regular := &File{Name: "file2", Parent: dir}
+
+	assert.False(t, IsBrokenSymlink(valid))
+	assert.True(t, IsBrokenSymlink(broken))
+	assert.False(t, IsBrokenSymlink(regular))
+	assert.False(t, IsBrokenSymlink(dir))
+}
+
+func TestBrokenSymlinkIsStoredDuringAnalysis(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.Symlink("file2", "test_dir/nested/valid")
+	assert.Nil(t, err)
+
+	analyzer := CreateAnalyzer()
+	dir := analyzer.AnalyzeDir(
+		"test_dir", func(_, _ string) bool { return false }, false,
+	).(*Dir)
+	<-analyzer.GetDoneChan()
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	// the analyzed tree is reported, not the current state of the filesystem
+	err = os.Remove("test_dir/nested/file2")
+	assert.Nil(t, err)
+
+	assert.Len(t, GetBrokenSymlinks(dir), 0)
+}
@@ -0,0 +1,74 @@
+package analyze
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// GetLargestFiles returns at most limit largest files in the whole subtree of the item
+// which pass the filter (nil filter accepts all files).
+// Files are sorted by disk usage or by apparent size, the largest first.
+// Hard links already counted elsewhere are skipped.
+func GetLargestFiles(
+	item fs.Item, limit int, apparentSize bool, filter func(fs.Item) bool,
+) fs.Files {
+	if limit <= 0 {
+		return fs.Files{}
+	}
+
+	top := &filesHeap{apparentSize: apparentSize}
+
+	walk(item, func(entry fs.Item) {
+		if entry.IsDir() || entry.GetFlag() == 'H' {
+			return
+		}
+		if filter != nil && !filter(entry) {
+			return
+		}
+		if top.Len() < limit {
+			heap.Push(top, entry)
+			return
+		}
+		if top.size(entry) > top.size(top.files[0]) {
+			top.files[0] = entry
+			heap.Fix(top, 0)
+		}
+	})
+
+	files := top.files
+	if apparentSize {
+		sort.Sort(fs.ByApparentSize(files))
+	} else {
+		sort.Sort(files)
+	}
+	return files
+}
+
+// filesHeap is min-heap of files keeping the smallest file on top
+type filesHeap struct {
+	files        fs.Files
+	apparentSize bool
+}
+
+func (h *filesHeap) size(item fs.Item) int64 {
+	if h.apparentSize {
+		return item.GetSize()
+	}
+	return item.GetUsage()
+}
+
+func (h *filesHeap) Len() int           { return len(h.files) }
+func (h *filesHeap) Less(i, j int) bool { return h.size(h.files[i]) < h.size(h.files[j]) }
+func (h *filesHeap) Swap(i, j int)      { h.files[i], h.files[j] = h.files[j], h.files[i] }
+
+func (h *filesHeap) Push(x interface{}) {
+	h.files = append(h.files, x.(fs.Item))
+}
+
+func (h *filesHeap) Pop() interface{} {
+	last := h.files[len(h.files)-1]
+	h.files = h.files[:len(h.files)-1]
+	return last
+}
@@ -0,0 +1,71 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestGetLargestFiles(t *testing.T) {
+	dir := getFilesForTop()
+
+	files := GetLargestFiles(dir, 2, false, nil)
+
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, "ccc", files[0].GetName())
+	assert.Equal(t, "aaa", files[1].GetName())
+}
+
+func TestGetLargestFilesByApparentSize(t *testing.T) {
+	dir := getFilesForTop()
+
+	files := GetLargestFiles(dir, 3, true, nil)
+
+	assert.Equal(t, 3, len(files))
+	assert.Equal(t, "bbb", files[0].GetName())
+	assert.Equal(t, "ccc", files[1].GetName())
+	assert.Equal(t, "aaa", files[2].GetName())
+}
+
+func TestGetLargestFilesWithFilter(t *testing.T) {
+	dir := getFilesForTop()
+
+	files := GetLargestFiles(dir, 10, false, func(item fs.Item) bool {
+		return item.GetName() != "ccc"
+	})
+
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, "aaa", files[0].GetName())
+	assert.Equal(t, "bbb", files[1].GetName())
+}
+
+func TestGetLargestFilesZeroLimit(t *testing.T) {
+	dir := getFilesForTop()
+
+	assert.Equal(t, 0, len(GetLargestFiles(dir, 0, false, nil)))
+}
+
+func getFilesForTop() *Dir {
+	dir := &Dir{
+		File: &File{
+			Name: "xxx",
+		},
+	}
+	subdir := &Dir{
+		File: &File{
+			Name:   "yyy",
+			Parent: dir,
+		},
+	}
+	subdir.Files = fs.Files{
+		&File{Name: "aaa", Size: 10, Usage: 8192, Parent: subdir},
+		&File{Name: "bbb", Size: 5000, Usage: 4096, Parent: subdir},
+		&File{Name: "ddd", Size: 5000, Usage: 20000, Flag: 'H', Parent: subdir},
+	}
+	dir.Files = fs.Files{
+		subdir,
+		&File{Name: "ccc", Size: 3000, Usage: 12288, Parent: dir},
+	}
+	return dir
+}
@@ -0,0 +1,26 @@
+package analyze
+
+import "github.com/ungtb10d/gdu/v5/pkg/fs"
+
+// walk calls fn for the given item and recursively for all items below it
+func walk(item fs.Item, fn func(fs.Item)) {
+	fn(item)
+	if !item.IsDir() {
+		return
+	}
+	for _, entry := range item.GetFiles() {
+		walk(entry, fn)
+	}
+}
+
+// ownSizes returns apparent size and disk usage of the item itself,
+// i.e. without its subitems and without already counted hard links
+func ownSizes(item fs.Item) (int64, int64) {
+	if item.IsDir() {
+		return dirOwnSize, dirOwnSize
+	}
+	if item.GetFlag() == 'H' {
+		return 0, 0
+	}
+	return item.GetSize(), item.GetUsage()
+}
@@ -15,10 +15,17 @@ type Item interface {
 	GetType() string
 	GetUsage() int64
 	GetMtime() time.Time
+	GetAtime() time.Time
+	GetCtime() time.Time
+	GetTarget() string
 	GetItemCount() int
 	GetParent() Item
 	SetParent(Item)
 	GetMultiLinkedInode() uint64
+	GetInode() uint64
+	GetMultiLinkedID() MultiLinkedID
+	GetUID() uint32
+	GetGID() uint32
 	EncodeJSON(writer io.Writer, topLevel bool) error
 	GetItemStats(linkedItems HardLinkedItems) (int, int64, int64)
 	UpdateStats(linkedItems HardLinkedItems)
@@ -30,8 +37,14 @@ type Item interface {
 // Files - slice of pointers to File
 type Files []Item
 
-// HardLinkedItems maps inode number to array of all hard linked items
-type HardLinkedItems map[uint64]Files
+// MultiLinkedID identifies hard linked file by device and inode number
+type MultiLinkedID struct {
+	Dev uint64
+	Ino uint64
+}
+
+// HardLinkedItems maps device and inode number to array of all hard linked items
+type HardLinkedItems map[MultiLinkedID]Files
 
 // IndexOf searches File in Files and returns its index
 func (f Files) IndexOf(file Item) (int, bool) {
@@ -102,3 +115,24 @@ type ByMtime Files
 func (f ByMtime) Len() int           { return len(f) }
 func (f ByMtime) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
 func (f ByMtime) Less(i, j int) bool { return f[i].GetMtime().After(f[j].GetMtime()) }
+
+// ByAtime sorts files by atime
+type ByAtime Files
+
+func (f ByAtime) Len() int           { return len(f) }
+func (f ByAtime) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f ByAtime) Less(i, j int) bool { return f[i].GetAtime().After(f[j].GetAtime()) }
+
+// ByCtime sorts files by ctime
+type ByCtime Files
+
+func (f ByCtime) Len() int           { return len(f) }
+func (f ByCtime) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f ByCtime) Less(i, j int) bool { return f[i].GetCtime().After(f[j].GetCtime()) }
+
+// ByOwner sorts files by id of the owning user
+type ByOwner Files
+
+func (f ByOwner) Len() int           { return len(f) }
+func (f ByOwner) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f ByOwner) Less(i, j int) bool { return f[i].GetUID() > f[j].GetUID() }
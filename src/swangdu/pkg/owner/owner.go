@@ -0,0 +1,84 @@
+package owner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Resolver translates user and group ids to names
+// using files in the /etc/passwd and /etc/group format
+type Resolver struct {
+	PasswdPath string
+	GroupPath  string
+	users      map[uint32]string
+	groups     map[uint32]string
+	once       sync.Once
+}
+
+// DefaultResolver reads the system user and group databases
+var DefaultResolver = &Resolver{PasswdPath: "/etc/passwd", GroupPath: "/etc/group"}
+
+// UserName returns name of the user with given id or the id itself if the user is unknown
+func (r *Resolver) UserName(uid uint32) string {
+	r.once.Do(r.load)
+	if name, ok := r.users[uid]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(uid), 10)
+}
+
+// GroupName returns name of the group with given id or the id itself if the group is unknown
+func (r *Resolver) GroupName(gid uint32) string {
+	r.once.Do(r.load)
+	if name, ok := r.groups[gid]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(gid), 10)
+}
+
+func (r *Resolver) load() {
+	r.users = readIDFile(r.PasswdPath)
+	r.groups = readIDFile(r.GroupPath)
+}
+
+// readIDFile reads names and ids from colon separated file, e.g. "root:x:0:0:..."
+// Missing or unreadable file results in empty map.
+func readIDFile(path string) map[uint32]string {
+	file, err := os.Open(path)
+	if err != nil {
+		return map[uint32]string{}
+	}
+	defer file.Close()
+
+	return parseIDFile(file)
+}
+
+func parseIDFile(input io.Reader) map[uint32]string {
+	names := make(map[uint32]string)
+
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		parts := strings.Split(line, ":")
+		if len(parts) < 3 {
+			continue
+		}
+
+		id, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := names[uint32(id)]; !ok {
+			names[uint32(id)] = parts[0]
+		}
+	}
+	return names
+}
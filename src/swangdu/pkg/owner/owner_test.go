@@ -0,0 +1,46 @@
+package owner
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIDFile(t *testing.T) {
+	names := parseIDFile(strings.NewReader(
+		"# comment\n" +
+			"root:x:0:0:root:/root:/bin/bash\n" +
+			"daemon:x:1:1:daemon:/usr/sbin:/usr/sbin/nologin\n" +
+			"broken\n" +
+			"nonum:x:abc:1::/:/bin/sh\n" +
+			"\n" +
+			"toor:x:0:0:root:/root:/bin/sh\n",
+	))
+
+	assert.Equal(t, map[uint32]string{0: "root", 1: "daemon"}, names)
+}
+
+func TestResolver(t *testing.T) {
+	err := os.WriteFile("passwd", []byte("root:x:0:0:root:/root:/bin/bash\nuser:x:1000:1000::/home/user:/bin/sh\n"), 0600)
+	assert.Nil(t, err)
+	defer os.Remove("passwd")
+	err = os.WriteFile("group", []byte("root:x:0:\nstaff:x:50:user\n"), 0600)
+	assert.Nil(t, err)
+	defer os.Remove("group")
+
+	resolver := &Resolver{PasswdPath: "passwd", GroupPath: "group"}
+
+	assert.Equal(t, "user", resolver.UserName(1000))
+	assert.Equal(t, "1001", resolver.UserName(1001))
+	assert.Equal(t, "staff", resolver.GroupName(50))
+	assert.Equal(t, "1000", resolver.GroupName(1000))
+}
+
+func TestResolverWithMissingFiles(t *testing.T) {
+	resolver := &Resolver{PasswdPath: "/xxx/passwd", GroupPath: "/xxx/group"}
+
+	assert.Equal(t, "0", resolver.UserName(0))
+	assert.Equal(t, "0", resolver.GroupName(0))
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// compressedOutput closes the compressor before the file so that all data is written
+type compressedOutput struct {
+	io.WriteCloser
+	file io.Closer
+}
+
+func (o *compressedOutput) Close() error {
+	err := o.WriteCloser.Close()
+	if cerr := o.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// CreateCompressedOutput returns output compressing written data by gzip or zstd
+// if the name of the file ends with .gz or .zst, the file itself otherwise
+func CreateCompressedOutput(file io.WriteCloser, name string) (io.WriteCloser, error) {
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		return &compressedOutput{WriteCloser: gzip.NewWriter(file), file: file}, nil
+	case strings.HasSuffix(name, ".zst"):
+		encoder, err := zstd.NewWriter(file)
+		if err != nil {
+			return nil, err
+		}
+		return &compressedOutput{WriteCloser: encoder, file: file}, nil
+	}
+	return file, nil
+}
+
+// createDecompressedReader returns reader decompressing the input
+// if it starts with magic bytes of gzip or zstd, the input as it is otherwise
+func createDecompressedReader(input io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(input)
+	magic, err := buffered.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return io.NopCloser(buffered), nil
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+type closableBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closableBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestCreateCompressedOutput(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		magic []byte
+	}{
+		{"report.json.gz", gzipMagic},
+		{"report.json.zst", zstdMagic},
+		{"report.json", []byte("[1,2")},
+	} {
+		buff := &closableBuffer{}
+		output, err := CreateCompressedOutput(buff, tc.name)
+		assert.Nil(t, err)
+
+		_, err = output.Write([]byte(`[1,2,{},[{"name":"/home/xxx"},{"name":"file","asize":5}]]`))
+		assert.Nil(t, err)
+		err = output.Close()
+		assert.Nil(t, err)
+		assert.True(t, buff.closed)
+		assert.True(t, bytes.HasPrefix(buff.Bytes(), tc.magic), tc.name)
+
+		dir, err := ReadAnalysis(&buff.Buffer)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, "file", dir.Files[0].GetName())
+	}
+}
+
+func TestReadGzipAnalysis(t *testing.T) {
+	data, err := os.ReadFile("../internal/testdata/test.json")
+	assert.Nil(t, err)
+
+	var buff bytes.Buffer
+	writer := gzip.NewWriter(&buff)
+	_, err = writer.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	compressedSize := int64(buff.Len())
+
+	reader := CreateAnalysisReader(&buff)
+	dir, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "gdu", dir.GetName())
+
+	// progress is counted in bytes of the compressed input
+	bytesRead, _, _ := reader.GetProgress()
+	assert.Equal(t, compressedSize, bytesRead)
+}
+
+func TestReadZstdAnalysis(t *testing.T) {
+	data, err := os.ReadFile("../internal/testdata/test.json")
+	assert.Nil(t, err)
+
+	var buff bytes.Buffer
+	writer, err := zstd.NewWriter(&buff)
+	assert.Nil(t, err)
+	_, err = writer.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	dir, err := ReadAnalysis(&buff)
+	assert.Nil(t, err)
+	assert.Equal(t, "gdu", dir.GetName())
+}
+
+func TestReadCorruptedGzipAnalysis(t *testing.T) {
+	buff := bytes.NewBuffer(append([]byte{0x1f, 0x8b}, []byte("not really gzip")...))
+
+	_, err := ReadAnalysis(buff)
+	assert.ErrorIs(t, err, gzip.ErrHeader)
+}
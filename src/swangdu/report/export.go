@@ -1,11 +1,10 @@
 package report
 
 import (
-	"bytes"
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strconv"
 	"sync"
@@ -19,11 +18,25 @@ import (
 	"github.com/fatih/color"
 )
 
+// formats of the exported analysis
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatHTML     = "html"
+	FormatFolded   = "folded"
+	FormatSnapshot = "snapshot"
+)
+
+// size of the buffer used for writing the export
+const exportBufferSize = 64 * 1024
+
 // UI struct
 type UI struct {
 	*common.UI
 	output       io.Writer
 	exportOutput io.Writer
+	format       string
+	maxDepth     int
 	red          *color.Color
 	orange       *color.Color
 	writtenChan  chan struct{}
@@ -47,6 +60,7 @@ func CreateExportUI(
 		},
 		output:       output,
 		exportOutput: exportOutput,
+		format:       FormatJSON,
 		writtenChan:  make(chan struct{}),
 	}
 	ui.red = color.New(color.FgRed).Add(color.Bold)
@@ -59,6 +73,21 @@ func CreateExportUI(
 	return ui
 }
 
+// SetFormat sets format of the exported analysis (FormatJSON, FormatCSV, FormatHTML or FormatFolded)
+func (ui *UI) SetFormat(format string) {
+	ui.format = format
+}
+
+// SetShowApparentSize sets if apparent size should be exported instead of disk usage in folded stacks
+func (ui *UI) SetShowApparentSize(value bool) {
+	ui.ShowApparentSize = value
+}
+
+// SetMaxDepth sets depth of the tree below which items are aggregated in folded stacks
+func (ui *UI) SetMaxDepth(depth int) {
+	ui.maxDepth = depth
+}
+
 // StartUILoop stub
 func (ui *UI) StartUILoop() error {
 	return nil
@@ -69,9 +98,16 @@ func (ui *UI) ListDevices(getter device.DevicesInfoGetter) error {
 	return errors.New("Exporting devices list is not supported")
 }
 
-// ReadAnalysis reads analysis report from JSON file
-func (ui *UI) ReadAnalysis(input io.Reader) error {
-	return errors.New("Reading analysis is not possible while exporting")
+// ReadAnalysis reads analysis report from JSON file and exports it,
+// analyses read from several files are merged into one tree
+func (ui *UI) ReadAnalysis(inputs ...io.Reader) error {
+	dir, err := CreateMergingAnalysisReader(inputs, ui.KeepInputPaths).Read()
+	if err != nil {
+		return err
+	}
+	dir.UpdateStats(make(fs.HardLinkedItems, 10))
+
+	return ui.export(dir)
 }
 
 // AnalyzePath analyzes recursively disk usage in given path
@@ -100,40 +136,69 @@ func (ui *UI) AnalyzePath(path string, _ fs.Item) error {
 
 	wait.Wait()
 
-	sort.Sort(dir.GetFiles())
+	if err = ui.export(dir); err != nil {
+		return err
+	}
 
-	var buff bytes.Buffer
+	if ui.ShowProgress {
+		ui.writtenChan <- struct{}{}
+		waitWritten.Wait()
+	}
 
-	buff.Write([]byte(`[1,2,{"progname":"gdu","progver":"`))
-	buff.Write([]byte(build.Version))
-	buff.Write([]byte(`","timestamp":`))
-	buff.Write([]byte(strconv.FormatInt(time.Now().Unix(), 10)))
-	buff.Write([]byte("},\n"))
+	return nil
+}
 
-	if err = dir.EncodeJSON(&buff, true); err != nil {
-		return err
+// export writes the analyzed tree to the export output in the chosen format
+func (ui *UI) export(dir fs.Item) error {
+	var err error
+
+	sort.Sort(dir.GetFiles())
+
+	// the export is streamed to the output through buffer of limited size
+	// so the whole encoded tree is never held in memory
+	output := bufio.NewWriterSize(ui.exportOutput, exportBufferSize)
+
+	switch ui.format {
+	case FormatCSV:
+		err = analyze.EncodeCSV(output, dir)
+	case FormatHTML:
+		err = WriteHTML(output, dir, ui.UseSIPrefix)
+	case FormatFolded:
+		err = analyze.EncodeFolded(output, dir, ui.ShowApparentSize, ui.maxDepth)
+	case FormatSnapshot:
+		err = WriteSnapshot(output, dir)
+	default:
+		err = writeJSON(output, dir)
 	}
-	if _, err = buff.Write([]byte("]\n")); err != nil {
+	if err != nil {
 		return err
 	}
-	if _, err = buff.WriteTo(ui.exportOutput); err != nil {
+	if err = output.Flush(); err != nil {
 		return err
 	}
 
-	switch f := ui.exportOutput.(type) {
-	case *os.File:
+	// closes also compressor of the output
+	if f, ok := ui.exportOutput.(io.Closer); ok {
 		err = f.Close()
 		if err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	if ui.ShowProgress {
-		ui.writtenChan <- struct{}{}
-		waitWritten.Wait()
+func writeJSON(output io.Writer, dir fs.Item) error {
+	header := `[1,2,{"progname":"gdu","progver":"` + build.Version +
+		`","timestamp":` + strconv.FormatInt(time.Now().Unix(), 10) + "},\n"
+	if _, err := io.WriteString(output, header); err != nil {
+		return err
 	}
 
-	return nil
+	if err := dir.EncodeJSON(output, true); err != nil {
+		return err
+	}
+	_, err := io.WriteString(output, "]\n")
+	return err
 }
 
 func (ui *UI) updateProgress() {
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ungtb10d/gdu/v5/internal/testdir"
 	"github.com/ungtb10d/gdu/v5/pkg/device"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,14 +62,75 @@ func TestShowDevices(t *testing.T) {
 	assert.Contains(t, err.Error(), "not supported")
 }
 
-func TestReadAnalysisWhileExporting(t *testing.T) {
+func TestReadAnalysisAndExport(t *testing.T) {
 	output := bytes.NewBuffer(make([]byte, 10))
-	reportOutput := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := bytes.NewBuffer(make([]byte, 0))
+
+	ui := CreateExportUI(output, reportOutput, false, true, false, false)
+	ui.SetFormat(FormatCSV)
+	err := ui.ReadAnalysis(
+		bytes.NewBufferString(`[1,2,3,[{"name":"/home/xxx"},{"name":"file","asize":5,"dsize":8}]]`),
+		bytes.NewBufferString(`[1,2,3,[{"name":"/home/yyy"},{"name":"file","asize":7,"dsize":8}]]`),
+	)
+
+	assert.Nil(t, err)
+	assert.Contains(t, reportOutput.String(), "merged/xxx/file,2,file,5,8,")
+	assert.Contains(t, reportOutput.String(), "merged/yyy/file,2,file,7,8,")
+}
+
+func TestReadAnalysisFromFileAndExport(t *testing.T) {
+	input, err := os.Open("../internal/testdata/test.json")
+	assert.Nil(t, err)
+	defer input.Close()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	err = ui.ReadAnalysis(input)
+	assert.Nil(t, err)
+
+	dir, err := ReadAnalysis(bytes.NewBuffer(reportOutput.Bytes()))
+	assert.Nil(t, err)
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	assert.Equal(t, "/home/gdu", dir.GetPath())
+	assert.Equal(t, 2, len(dir.Files))
+	assert.Equal(t, 6, dir.ItemCount)
+	assert.Equal(t, int64(4638+1410+4974+3205+2*4096), dir.GetSize())
+}
+
+func TestMergeAnalysesAndExportJSON(t *testing.T) {
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetKeepInputPaths(true)
+	err := ui.ReadAnalysis(
+		bytes.NewBufferString(`[1,2,3,[{"name":"/home/xxx"},{"name":"file","asize":5,"dsize":8}]]`),
+		bytes.NewBufferString(`[1,2,3,[{"name":"/srv"},{"name":"file","asize":7,"dsize":8}]]`),
+	)
+	assert.Nil(t, err)
+
+	dir, err := ReadAnalysis(bytes.NewBuffer(reportOutput.Bytes()))
+	assert.Nil(t, err)
+	dir.UpdateStats(make(fs.HardLinkedItems))
+
+	assert.Equal(t, "/", dir.GetPath())
+	assert.Equal(t, []string{"home", "srv"}, []string{dir.Files[0].GetName(), dir.Files[1].GetName()})
+	assert.Equal(t, "xxx", dir.Files[0].GetFiles()[0].GetName())
+	assert.Equal(t, int64(16+4*4096), dir.GetUsage())
+}
+
+func TestReadWrongAnalysisAndExport(t *testing.T) {
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := bytes.NewBuffer(make([]byte, 0))
 
 	ui := CreateExportUI(output, reportOutput, false, true, false, false)
-	err := ui.ReadAnalysis(output)
+	err := ui.ReadAnalysis(bytes.NewBufferString(`{}`))
 
-	assert.Contains(t, err.Error(), "not possible while exporting")
+	assert.Contains(t, err.Error(), "does not contain top level array")
+	assert.Empty(t, reportOutput.String())
 }
 
 func TestExportToFile(t *testing.T) {
@@ -93,7 +156,7 @@ func TestExportToFile(t *testing.T) {
 	assert.Nil(t, err)
 	_, err = reportOutput.Seek(0, 0)
 	assert.Nil(t, err)
-	buff := make([]byte, 200)
+	buff := make([]byte, 1000)
 	_, err = reportOutput.Read(buff)
 	assert.Nil(t, err)
 
@@ -129,3 +192,108 @@ func TestFormatSizeDec(t *testing.T) {
 	assert.Contains(t, ui.formatSize(1<<50+1), "PB")
 	assert.Contains(t, ui.formatSize(1<<60+1), "EB")
 }
+
+func TestAnalyzePathToFolded(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetFormat(FormatFolded)
+	ui.SetShowApparentSize(true)
+	ui.SetMaxDepth(2)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, reportOutput.String(), "test_dir;nested;file2 2\n")
+	assert.Contains(t, reportOutput.String(), "test_dir;nested;subnested 4101\n")
+	assert.NotContains(t, reportOutput.String(), "subnested;file")
+}
+
+func TestAnalyzePathToCSV(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetFormat(FormatCSV)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, reportOutput.String(), "path,depth,type,asize,dsize,items,mtime,flag,inode,parent\n")
+	assert.Contains(t, reportOutput.String(), "test_dir/nested/file2,2,file,2,")
+}
+
+func TestAnalyzePathStreamsExport(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &countingOutput{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, reportOutput.writes)
+	assert.Contains(t, reportOutput.String(), `"name":"nested"`)
+}
+
+func TestAnalyzePathWithBrokenOutput(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+
+	for _, format := range []string{FormatJSON, FormatCSV, FormatHTML, FormatFolded} {
+		ui := CreateExportUI(output, &BrokenOutput{}, false, false, false, false)
+		ui.SetFormat(format)
+		err := ui.AnalyzePath("test_dir", nil)
+		assert.ErrorContains(t, err, "IO error", format)
+	}
+}
+
+type countingOutput struct {
+	bytes.Buffer
+	writes int
+}
+
+func (o *countingOutput) Write(p []byte) (n int, err error) {
+	o.writes++
+	return o.Buffer.Write(p)
+}
+
+type BrokenOutput struct{}
+
+func (o *BrokenOutput) Write(p []byte) (n int, err error) {
+	return 0, errors.New("IO error")
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetIgnoreDirPaths([]string{"test_dir/nested/subnested"})
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+	assert.Contains(t, reportOutput.String(), `{"name":"subnested","excluded":"pattern"}`)
+
+	dir, err := ReadAnalysis(bytes.NewBuffer(reportOutput.Bytes()))
+	assert.Nil(t, err)
+
+	var exported bytes.Buffer
+	err = writeJSON(&exported, dir)
+	assert.Nil(t, err)
+
+	// everything except the header with timestamp is the same
+	skipHeader := func(b []byte) []byte { return b[bytes.IndexByte(b, '\n'):] }
+	assert.Equal(t, string(skipHeader(reportOutput.Bytes())), string(skipHeader(exported.Bytes())))
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	_ "embed" // for the HTML template
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// items smaller than this fraction of the total usage are merged together
+// so the report stays small even for trees with millions of files
+const htmlMinFraction = 1e-5
+
+//go:embed html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlTemplateText))
+
+// htmlItem is item of the analyzed tree embedded into the HTML report
+type htmlItem struct {
+	Name      string      `json:"n"`
+	Usage     int64       `json:"u"`
+	Size      int64       `json:"s"`
+	ItemCount int         `json:"i"`
+	Dir       bool        `json:"d,omitempty"`
+	Merged    bool        `json:"m,omitempty"`
+	Items     []*htmlItem `json:"c,omitempty"`
+}
+
+type htmlReport struct {
+	Path        string
+	Version     string
+	Generated   string
+	UseSIPrefix bool
+	Root        *htmlItem
+}
+
+// WriteHTML writes self-contained HTML report with sunburst chart and table of the analyzed tree
+func WriteHTML(w io.Writer, dir fs.Item, useSIPrefix bool) error {
+	minUsage := int64(float64(dir.GetUsage()) * htmlMinFraction)
+
+	return htmlTemplate.Execute(w, htmlReport{
+		Path:        strings.TrimPrefix(dir.GetPath(), build.RootPathPrefix),
+		Version:     build.Version,
+		Generated:   time.Now().Format("2006-01-02 15:04:05"),
+		UseSIPrefix: useSIPrefix,
+		Root:        createHTMLItem(dir, minUsage),
+	})
+}
+
+func createHTMLItem(item fs.Item, minUsage int64) *htmlItem {
+	res := &htmlItem{
+		Name:      item.GetName(),
+		Usage:     item.GetUsage(),
+		Size:      item.GetSize(),
+		ItemCount: item.GetItemCount(),
+		Dir:       item.IsDir(),
+	}
+	if !item.IsDir() {
+		return res
+	}
+
+	files := make(fs.Files, len(item.GetFiles()))
+	copy(files, item.GetFiles())
+	sort.Sort(files)
+
+	var merged *htmlItem
+	for _, file := range files {
+		if file.GetUsage() >= minUsage && file.GetUsage() > 0 {
+			res.Items = append(res.Items, createHTMLItem(file, minUsage))
+			continue
+		}
+		if merged == nil {
+			merged = &htmlItem{Merged: true}
+		}
+		merged.Usage += file.GetUsage()
+		merged.Size += file.GetSize()
+		merged.ItemCount += file.GetItemCount()
+	}
+	if merged != nil {
+		res.Items = append(res.Items, merged)
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestAnalyzePathToHTML(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := bytes.NewBuffer(make([]byte, 10))
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetFormat(FormatHTML)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	html := reportOutput.String()
+	assert.Contains(t, html, "<title>Disk usage of test_dir</title>")
+	assert.Contains(t, html, `"n":"nested"`)
+	assert.Contains(t, html, `"n":"file2"`)
+	assert.Contains(t, html, `const rootPath = "test_dir";`)
+	assert.NotContains(t, html, "src=")
+	assert.NotContains(t, html, "href=")
+}
+
+func TestWriteHTMLEscapesNames(t *testing.T) {
+	dir := &analyze.Dir{
+		File:     &analyze.File{Name: "<b>dir</b>", Usage: 10, Size: 10},
+		BasePath: ".",
+	}
+	dir.Files = fs.Files{
+		&analyze.File{Name: "</script><script>alert(1)</script>", Usage: 10, Size: 10, Parent: dir},
+	}
+
+	var buff bytes.Buffer
+	err := WriteHTML(&buff, dir, true)
+	assert.Nil(t, err)
+
+	html := buff.String()
+	assert.NotContains(t, html, "<b>dir</b>")
+	assert.NotContains(t, html, "</script><script>alert(1)")
+	assert.Contains(t, html, "const useSIPrefix =  true ;")
+}
+
+func TestCreateHTMLItemMergesSmallItems(t *testing.T) {
+	dir := &analyze.Dir{
+		File:      &analyze.File{Name: "dir", Usage: 1e6 + 30, Size: 1e6 + 30},
+		BasePath:  ".",
+		ItemCount: 4,
+	}
+	dir.Files = fs.Files{
+		&analyze.File{Name: "small1", Usage: 10, Size: 11, Parent: dir},
+		&analyze.File{Name: "big", Usage: 1e6, Size: 1e6, Parent: dir},
+		&analyze.File{Name: "small2", Usage: 20, Size: 21, Parent: dir},
+	}
+
+	item := createHTMLItem(dir, 100)
+
+	assert.True(t, item.Dir)
+	assert.Len(t, item.Items, 2)
+	assert.Equal(t, "big", item.Items[0].Name)
+	assert.True(t, item.Items[1].Merged)
+	assert.Equal(t, int64(30), item.Items[1].Usage)
+	assert.Equal(t, int64(32), item.Items[1].Size)
+	assert.Equal(t, 2, item.Items[1].ItemCount)
+	assert.Equal(t, "small1", dir.Files[0].GetName())
+}
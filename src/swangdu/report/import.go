@@ -1,61 +1,267 @@
 package report
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"os"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 )
 
+// AnalysisReader reads analysis report from JSON file or binary snapshot item by item
+// so the whole file is never loaded into memory.
+// File compressed by gzip or zstd is decompressed on the fly.
+// Analyses read from several inputs are merged into one tree.
+type AnalysisReader struct {
+	inputs    []*countingReader
+	decoder   *json.Decoder
+	buff      []byte
+	itemCount int64
+	totalSize int64
+	keepPaths bool
+}
+
+// countingReader counts bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	atomic.AddInt64(&r.count, int64(n))
+	return n, err
+}
+
+// CreateAnalysisReader creates reader of the analysis
+func CreateAnalysisReader(input io.Reader) *AnalysisReader {
+	return CreateMergingAnalysisReader([]io.Reader{input}, false)
+}
+
+// CreateMergingAnalysisReader creates reader of analyses which are merged into one tree.
+// If keepPaths is true, every analysis is placed at its original absolute path,
+// otherwise under synthetic root directory.
+func CreateMergingAnalysisReader(inputs []io.Reader, keepPaths bool) *AnalysisReader {
+	r := &AnalysisReader{keepPaths: keepPaths}
+
+	// total size is known only if sizes of all the inputs are known
+	sizeKnown := true
+	for _, input := range inputs {
+		r.inputs = append(r.inputs, &countingReader{reader: input})
+
+		f, ok := input.(*os.File)
+		if !ok {
+			sizeKnown = false
+			continue
+		}
+		if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+			r.totalSize += info.Size()
+		} else {
+			sizeKnown = false
+		}
+	}
+	if !sizeKnown {
+		r.totalSize = 0
+	}
+	return r
+}
+
+// GetProgress returns number of bytes (of the compressed input) and items read so far
+// and total size of the input (zero if not known).
+// It is safe to call it while the analysis is being read.
+func (r *AnalysisReader) GetProgress() (bytesRead int64, itemCount int, totalSize int64) {
+	for _, input := range r.inputs {
+		bytesRead += atomic.LoadInt64(&input.count)
+	}
+	return bytesRead, int(atomic.LoadInt64(&r.itemCount)), r.totalSize
+}
+
 // ReadAnalysis reads analysis report from JSON file and returns directory item
 func ReadAnalysis(input io.Reader) (*analyze.Dir, error) {
-	var data interface{}
+	return CreateAnalysisReader(input).Read()
+}
+
+// Read reads the analysis and returns directory item
+func (r *AnalysisReader) Read() (*analyze.Dir, error) {
+	dirs := make([]*analyze.Dir, 0, len(r.inputs))
+	for _, input := range r.inputs {
+		dir, err := r.readInput(input)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, dir)
+	}
+
+	if len(dirs) == 1 {
+		return dirs[0], nil
+	}
+	return analyze.MergeDirs(dirs, r.keepPaths), nil
+}
 
-	var buff bytes.Buffer
-	if _, err := buff.ReadFrom(input); err != nil {
+// readInput reads analysis from one input
+func (r *AnalysisReader) readInput(counting *countingReader) (*analyze.Dir, error) {
+	decompressed, err := createDecompressedReader(counting)
+	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(buff.Bytes(), &data); err != nil {
+	defer decompressed.Close()
+
+	input := bufio.NewReader(decompressed)
+	if magic, _ := input.Peek(len(snapshotMagic)); bytes.Equal(magic, snapshotMagic) {
+		return r.readSnapshot(input)
+	}
+	r.decoder = json.NewDecoder(input)
+
+	tok, err := r.token()
+	if err != nil {
 		return nil, err
 	}
+	if tok != json.Delim('[') {
+		return nil, r.errorf("JSON file does not contain top level array")
+	}
 
-	dataArray, ok := data.([]interface{})
-	if !ok {
-		return nil, errors.New("JSON file does not contain top level array")
+	// skip format version and metadata
+	for i := 0; i < 3; i++ {
+		if !r.decoder.More() {
+			return nil, r.errorf("Top level array must have at least 4 items")
+		}
+		var skipped json.RawMessage
+		if err := r.decode(&skipped); err != nil {
+			return nil, err
+		}
 	}
-	if len(dataArray) < 4 {
-		return nil, errors.New("Top level array must have at least 4 items")
+
+	if !r.decoder.More() {
+		return nil, r.errorf("Top level array must have at least 4 items")
+	}
+	tok, err = r.token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('[') {
+		return nil, r.errorf("Array of maps not found in the top level array on 4th position")
 	}
 
-	items, ok := dataArray[3].([]interface{})
-	if !ok {
-		return nil, errors.New("Array of maps not found in the top level array on 4th position")
+	return r.readDir(0)
+}
+
+// readDir reads directory after the opening bracket of its array,
+// items without device number are on the same device as their parent
+func (r *AnalysisReader) readDir(parentDev uint64) (*analyze.Dir, error) {
+	tok, err := r.token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, r.errorf("Directory item is not a map")
+	}
+	dirMap, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := r.createDir(dirMap, parentDev)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, err := r.token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok {
+		case json.Delim(']'):
+			return dir, nil
+		case json.Delim('{'):
+			item, err := r.readObject()
+			if err != nil {
+				return nil, err
+			}
+			file, err := r.createFile(item, dir.Dev)
+			if err != nil {
+				return nil, err
+			}
+			file.Parent = dir
+			dir.AddFile(file)
+		case json.Delim('['):
+			subdir, err := r.readDir(dir.Dev)
+			if err != nil {
+				return nil, err
+			}
+			subdir.Parent = dir
+			dir.AddFile(subdir)
+		}
+	}
+}
+
+// readObject reads members of object after its opening brace
+func (r *AnalysisReader) readObject() (map[string]interface{}, error) {
+	item := make(map[string]interface{})
+	for r.decoder.More() {
+		tok, err := r.token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		var value interface{}
+		if err := r.decode(&value); err != nil {
+			return nil, err
+		}
+		item[key] = value
 	}
 
-	return processDir(items)
+	// closing brace
+	if _, err := r.token(); err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&r.itemCount, 1)
+	return item, nil
 }
 
-func processDir(items []interface{}) (*analyze.Dir, error) {
+func (r *AnalysisReader) createDir(dirMap map[string]interface{}, parentDev uint64) (*analyze.Dir, error) {
 	dir := &analyze.Dir{
 		File: &analyze.File{
 			Flag: ' ',
 		},
 	}
-	dirMap, ok := items[0].(map[string]interface{})
-	if !ok {
-		return nil, errors.New("Directory item is not a map")
-	}
 	name, ok := dirMap["name"].(string)
 	if !ok {
-		return nil, errors.New("Directory name is not a string")
+		return nil, r.errorf("Directory name is not a string")
 	}
 	if mtime, ok := dirMap["mtime"].(float64); ok {
 		dir.Mtime = time.Unix(int64(mtime), 0)
 	}
+	if atime, ok := dirMap["atime"].(float64); ok {
+		dir.Atime = time.Unix(int64(atime), 0)
+	}
+	if ctime, ok := dirMap["ctime"].(float64); ok {
+		dir.Ctime = time.Unix(int64(ctime), 0)
+	}
+	if target, ok := dirMap["target"].(string); ok {
+		dir.Target = target
+		dir.Flag = '@'
+	}
+	if _, ok := dirMap["read_error"].(bool); ok {
+		dir.Flag = '!'
+	}
+	if errs, ok := dirMap["errors"].([]interface{}); ok {
+		dir.Errors = readErrors(errs)
+	}
+	if ino, ok := dirMap["ino"].(float64); ok {
+		dir.Ino = uint64(ino)
+	}
+	dir.Dev = readDev(dirMap, parentDev)
+	readExtendedInfo(dirMap, dir.File)
 
 	slashPos := strings.LastIndex(name, "/")
 	if slashPos > -1 {
@@ -64,46 +270,127 @@ func processDir(items []interface{}) (*analyze.Dir, error) {
 	} else {
 		dir.Name = name
 	}
+	return dir, nil
+}
 
-	for _, v := range items[1:] {
-		switch item := v.(type) {
-		case map[string]interface{}:
-			file := &analyze.File{}
-			file.Name = item["name"].(string)
+func (r *AnalysisReader) createFile(item map[string]interface{}, parentDev uint64) (*analyze.File, error) {
+	file := &analyze.File{}
+	name, ok := item["name"].(string)
+	if !ok {
+		return nil, r.errorf("File name is not a string")
+	}
+	file.Name = name
 
-			if asize, ok := item["asize"].(float64); ok {
-				file.Size = int64(asize)
-			}
-			if dsize, ok := item["dsize"].(float64); ok {
-				file.Usage = int64(dsize)
-			}
-			if mtime, ok := item["mtime"].(float64); ok {
-				file.Mtime = time.Unix(int64(mtime), 0)
-			}
-			if _, ok := item["notreg"].(bool); ok {
-				file.Flag = '@'
-			} else {
-				file.Flag = ' '
-			}
-			if mli, ok := item["ino"].(float64); ok {
-				file.Mli = uint64(mli)
-			}
-			if _, ok := item["hlnkc"].(bool); ok {
-				file.Flag = 'H'
-			}
+	if asize, ok := item["asize"].(float64); ok {
+		file.Size = int64(asize)
+	}
+	if dsize, ok := item["dsize"].(float64); ok {
+		file.Usage = int64(dsize)
+	}
+	if mtime, ok := item["mtime"].(float64); ok {
+		file.Mtime = time.Unix(int64(mtime), 0)
+	}
+	if atime, ok := item["atime"].(float64); ok {
+		file.Atime = time.Unix(int64(atime), 0)
+	}
+	if ctime, ok := item["ctime"].(float64); ok {
+		file.Ctime = time.Unix(int64(ctime), 0)
+	}
+	if _, ok := item["notreg"].(bool); ok {
+		file.Flag = '@'
+	} else if _, ok := item["dsize"]; ok {
+		file.Flag = analyze.GetUsageFlag(file.Size, file.Usage)
+	} else {
+		file.Flag = ' '
+	}
+	if _, ok := item["read_error"].(bool); ok {
+		file.Flag = '!'
+	}
+	if reason, ok := item["excluded"].(string); ok {
+		file.Flag = analyze.GetExcludedFlag(reason)
+	}
+	if ino, ok := item["ino"].(float64); ok {
+		file.Ino = uint64(ino)
+	}
+	// only hard links are identified by inode number, as it is done during the analysis,
+	// links counted more than once are flagged when stats are updated
+	if hlnkc, _ := item["hlnkc"].(bool); hlnkc {
+		file.Mli = file.Ino
+		if nlink, ok := item["nlink"].(float64); ok {
+			file.Nlink = uint32(nlink)
+		}
+	}
+	file.Dev = readDev(item, parentDev)
+	if target, ok := item["target"].(string); ok {
+		file.Target = target
+	}
+	file.BrokenLink, _ = item["broken"].(bool)
+	readExtendedInfo(item, file)
+	return file, nil
+}
 
-			file.Parent = dir
+func readDev(item map[string]interface{}, parentDev uint64) uint64 {
+	if dev, ok := item["dev"].(float64); ok {
+		return uint64(dev)
+	}
+	return parentDev
+}
 
-			dir.AddFile(file)
-		case []interface{}:
-			subdir, err := processDir(item)
-			if err != nil {
-				return nil, err
-			}
-			subdir.Parent = dir
-			dir.AddFile(subdir)
-		}
+// readExtendedInfo reads owner and mode written in the extended mode of ncdu
+func readExtendedInfo(item map[string]interface{}, file *analyze.File) {
+	if uid, ok := item["uid"].(float64); ok {
+		file.UID = uint32(uid)
 	}
+	if gid, ok := item["gid"].(float64); ok {
+		file.GID = uint32(gid)
+	}
+	if mode, ok := item["mode"].(float64); ok {
+		file.Mode = uint32(mode)
+	}
+}
 
-	return dir, nil
+func (r *AnalysisReader) token() (json.Token, error) {
+	tok, err := r.decoder.Token()
+	return tok, r.wrapError(err)
+}
+
+func (r *AnalysisReader) decode(v interface{}) error {
+	return r.wrapError(r.decoder.Decode(v))
+}
+
+// wrapError adds position in the input to errors caused by malformed JSON
+func (r *AnalysisReader) wrapError(err error) error {
+	var syntaxErr *json.SyntaxError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("%s (at byte %d)", syntaxErr, syntaxErr.Offset)
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return r.errorf("unexpected end of JSON input")
+	}
+	return err
+}
+
+func (r *AnalysisReader) errorf(msg string) error {
+	return fmt.Errorf("%s (at byte %d)", msg, r.decoder.InputOffset())
+}
+
+func readErrors(items []interface{}) []*analyze.ScanError {
+	errs := make([]*analyze.ScanError, 0, len(items))
+	for _, v := range items {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scanErr := &analyze.ScanError{}
+		scanErr.Name, _ = item["name"].(string)
+		scanErr.Op, _ = item["op"].(string)
+		scanErr.Msg, _ = item["msg"].(string)
+		if errno, ok := item["errno"].(float64); ok {
+			scanErr.Errno = syscall.Errno(errno)
+		}
+		errs = append(errs, scanErr)
+	}
+	return errs
 }
@@ -2,10 +2,15 @@ package report
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/stretchr/testify/assert"
@@ -18,15 +23,19 @@ func init() {
 func TestReadAnalysis(t *testing.T) {
 	buff := bytes.NewBuffer([]byte(`
 		[1,2,{"progname":"gdu","progver":"development","timestamp":1626806293},
-		[{"name":"/home/xxx","mtime":1629333600},
+		[{"name":"/home/xxx","mtime":1629333600,"atime":1629420000,"ctime":1629506400},
 		{"name":"gdu.json","asize":33805233,"dsize":33808384},
+		{"name":"vm.img","asize":1073741824,"dsize":1048576},
 		{"name":"sock","notreg":true},
 		[{"name":"app"},
 		{"name":"app.go","asize":4638,"dsize":8192},
 		{"name":"app_linux_test.go","asize":1410,"dsize":4096},
-		{"name":"app_linux_test2.go","ino":1234,"hlnkc":true,"asize":1410,"dsize":4096},
+		{"name":"app_linux_test2.go","ino":1234,"dev":42,"hlnkc":true,"asize":1410,"dsize":4096},
 		{"name":"app_test.go","asize":4974,"dsize":8192}],
-		{"name":"main.go","asize":3205,"dsize":4096,"mtime":1629333600}]]
+		{"name":"main.go","asize":3205,"dsize":4096,"mtime":1629333600,"atime":1660869600,"ctime":1629506400},
+		{"name":"latest","notreg":true,"target":"app/app.go"},
+		{"name":"dangling","notreg":true,"target":"missing","broken":true},
+		[{"name":"private","read_error":true,"errors":[{"name":"","op":"readdir","errno":13,"msg":"permission denied"}]}]]]
 	`))
 
 	dir, err := ReadAnalysis(buff)
@@ -35,11 +44,31 @@ func TestReadAnalysis(t *testing.T) {
 	assert.Equal(t, "xxx", dir.GetName())
 	assert.Equal(t, "/home/xxx", dir.GetPath())
 	assert.Equal(t, 2021, dir.GetMtime().Year())
-	assert.Equal(t, 2021, dir.Files[3].GetMtime().Year())
-	alt2 := dir.Files[2].(*analyze.Dir).Files[2].(*analyze.File)
+	assert.Equal(t, 2021, dir.Files[4].GetMtime().Year())
+	assert.Equal(t, 20, dir.GetAtime().Day())
+	assert.Equal(t, 21, dir.GetCtime().Day())
+	assert.Equal(t, 2022, dir.Files[4].GetAtime().Year())
+	assert.Equal(t, 21, dir.Files[4].GetCtime().Day())
+	assert.Equal(t, ' ', dir.Files[0].GetFlag())
+	assert.Equal(t, 'S', dir.Files[1].GetFlag())
+	alt2 := dir.Files[3].(*analyze.Dir).Files[2].(*analyze.File)
 	assert.Equal(t, "app_linux_test2.go", alt2.Name)
 	assert.Equal(t, uint64(1234), alt2.Mli)
-	assert.Equal(t, 'H', alt2.Flag)
+	assert.Equal(t, uint64(42), alt2.Dev)
+	assert.Equal(t, ' ', alt2.Flag)
+	assert.Equal(t, "app/app.go", dir.Files[5].GetTarget())
+	assert.Equal(t, '@', dir.Files[5].GetFlag())
+
+	assert.False(t, analyze.IsBrokenSymlink(dir.Files[5]))
+	assert.Equal(t, "missing", dir.Files[6].GetTarget())
+	assert.True(t, analyze.IsBrokenSymlink(dir.Files[6]))
+
+	private := dir.Files[7].(*analyze.Dir)
+	assert.Equal(t, '!', private.Flag)
+	assert.Len(t, private.Errors, 1)
+	assert.Equal(t, analyze.OpReadDir, private.Errors[0].Op)
+	assert.Equal(t, syscall.Errno(13), private.Errors[0].Errno)
+	assert.Equal(t, "permission denied", private.Errors[0].Msg)
 }
 
 func TestReadAnalysisWithEmptyInput(t *testing.T) {
@@ -47,7 +76,7 @@ func TestReadAnalysisWithEmptyInput(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "unexpected end of JSON input", err.Error())
+	assert.Equal(t, "unexpected end of JSON input (at byte 0)", err.Error())
 }
 
 func TestReadAnalysisWithEmptyDict(t *testing.T) {
@@ -55,7 +84,7 @@ func TestReadAnalysisWithEmptyDict(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "JSON file does not contain top level array", err.Error())
+	assert.Equal(t, "JSON file does not contain top level array (at byte 1)", err.Error())
 }
 
 func TestReadFromBrokenInput(t *testing.T) {
@@ -69,7 +98,7 @@ func TestReadAnalysisWithEmptyArray(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "Top level array must have at least 4 items", err.Error())
+	assert.Equal(t, "Top level array must have at least 4 items (at byte 1)", err.Error())
 }
 
 func TestReadAnalysisWithWrongContent(t *testing.T) {
@@ -77,7 +106,7 @@ func TestReadAnalysisWithWrongContent(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "Array of maps not found in the top level array on 4th position", err.Error())
+	assert.Equal(t, "Array of maps not found in the top level array on 4th position (at byte 8)", err.Error())
 }
 
 func TestReadAnalysisWithEmptyDirContent(t *testing.T) {
@@ -85,7 +114,7 @@ func TestReadAnalysisWithEmptyDirContent(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "Directory name is not a string", err.Error())
+	assert.Equal(t, "Directory name is not a string (at byte 10)", err.Error())
 }
 
 func TestReadAnalysisWithWrongDirItem(t *testing.T) {
@@ -93,7 +122,7 @@ func TestReadAnalysisWithWrongDirItem(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "Directory item is not a map", err.Error())
+	assert.Equal(t, "Directory item is not a map (at byte 9)", err.Error())
 }
 
 func TestReadAnalysisWithWrongSubdirItem(t *testing.T) {
@@ -101,7 +130,157 @@ func TestReadAnalysisWithWrongSubdirItem(t *testing.T) {
 
 	_, err := ReadAnalysis(buff)
 
-	assert.Equal(t, "Directory item is not a map", err.Error())
+	assert.Equal(t, "Directory item is not a map (at byte 26)", err.Error())
+}
+
+func TestReadAnalysisWithWrongFileName(t *testing.T) {
+	buff := bytes.NewBuffer([]byte(`[1,2,3,[{"name":"xxx"}, {"name":5}]]`))
+
+	_, err := ReadAnalysis(buff)
+
+	assert.Equal(t, "File name is not a string (at byte 34)", err.Error())
+}
+
+func TestReadAnalysisWithSyntaxError(t *testing.T) {
+	buff := bytes.NewBuffer([]byte(`[1,2,{"progname":"gdu"},
+		[{"name":"xxx"},
+		{"name":"file" "asize":1}]]`))
+
+	_, err := ReadAnalysis(buff)
+
+	assert.Equal(t, "invalid character '\"' after object key:value pair (at byte 62)", err.Error())
+}
+
+func TestReadAnalysisWithTruncatedInput(t *testing.T) {
+	buff := bytes.NewBuffer([]byte(`[1,2,3,[{"name":"xxx"},{"name":"file"}`))
+
+	_, err := ReadAnalysis(buff)
+
+	assert.Equal(t, "unexpected end of JSON input (at byte 38)", err.Error())
+}
+
+func TestAnalysisReaderProgress(t *testing.T) {
+	input := `[1,2,3,[{"name":"xxx"},{"name":"file"},[{"name":"sub"},{"name":"file2"}]]]`
+	reader := CreateAnalysisReader(bytes.NewBuffer([]byte(input)))
+
+	dir, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "xxx", dir.GetName())
+
+	bytesRead, itemCount, totalSize := reader.GetProgress()
+	assert.Equal(t, int64(len(input)), bytesRead)
+	assert.Equal(t, 4, itemCount)
+	assert.Equal(t, int64(0), totalSize)
+}
+
+func TestAnalysisReaderTotalSizeOfFile(t *testing.T) {
+	f, err := os.Open("../internal/testdata/test.json")
+	assert.Nil(t, err)
+	defer f.Close()
+
+	reader := CreateAnalysisReader(f)
+	_, err = reader.Read()
+	assert.Nil(t, err)
+
+	bytesRead, _, totalSize := reader.GetProgress()
+	info, _ := f.Stat()
+	assert.Equal(t, info.Size(), totalSize)
+	assert.Equal(t, totalSize, bytesRead)
+}
+
+func TestReadMergedAnalyses(t *testing.T) {
+	first := `[1,2,3,[{"name":"/home/xxx"},{"name":"file","asize":5,"dsize":8}]]`
+	second := `[1,2,3,[{"name":"/home/yyy"},{"name":"file","asize":7,"dsize":8}]]`
+	reader := CreateMergingAnalysisReader(
+		[]io.Reader{bytes.NewBufferString(first), bytes.NewBufferString(second)},
+		true,
+	)
+
+	dir, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, "/", dir.GetPath())
+	home := dir.Files[0].(*analyze.Dir)
+	assert.Equal(t, "/home/xxx/file", home.Files[0].(*analyze.Dir).Files[0].GetPath())
+	assert.Equal(t, "/home/yyy/file", home.Files[1].(*analyze.Dir).Files[0].GetPath())
+
+	bytesRead, itemCount, totalSize := reader.GetProgress()
+	assert.Equal(t, int64(len(first)+len(second)), bytesRead)
+	assert.Equal(t, 4, itemCount)
+	assert.Equal(t, int64(0), totalSize)
+}
+
+func TestReadMergedAnalysesWithWrongInput(t *testing.T) {
+	reader := CreateMergingAnalysisReader(
+		[]io.Reader{bytes.NewBufferString(`[1,2,3,[{"name":"xxx"}]]`), &BrokenInput{}},
+		false,
+	)
+
+	_, err := reader.Read()
+	assert.Equal(t, "IO error", err.Error())
+}
+
+func TestReadNcduExport(t *testing.T) {
+	f, err := os.Open("../internal/testdata/ncdu.json")
+	assert.Nil(t, err)
+	defer f.Close()
+
+	dir, err := ReadAnalysis(f)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "/home/user", dir.GetPath())
+	assert.Equal(t, uint64(2049), dir.Dev)
+	assert.Equal(t, uint32(1000), dir.GetUID())
+	assert.Equal(t, uint32(16877), dir.Mode)
+
+	notes := dir.Files[0].(*analyze.File)
+	assert.Equal(t, uint64(2049), notes.Dev)
+	assert.Equal(t, uint64(0), notes.Mli)
+	assert.Equal(t, uint64(131074), notes.Ino)
+	assert.Equal(t, uint32(33188), notes.Mode)
+
+	link1 := dir.Files[1].(*analyze.File)
+	docs := dir.Files[2].(*analyze.Dir)
+	link2 := docs.Files[0].(*analyze.File)
+	assert.Equal(t, link1.GetMultiLinkedID(), link2.GetMultiLinkedID())
+	assert.Equal(t, uint32(2), link2.Nlink)
+	assert.Equal(t, '!', docs.Files[1].GetFlag())
+	assert.Equal(t, '@', docs.Files[2].GetFlag())
+
+	assert.Equal(t, '!', dir.Files[3].GetFlag())
+	assert.Equal(t, "otherfs", analyze.GetExcludedReason(dir.Files[4].GetFlag()))
+	assert.Equal(t, "kernfs", analyze.GetExcludedReason(dir.Files[5].GetFlag()))
+	assert.Equal(t, "pattern", analyze.GetExcludedReason(dir.Files[6].GetFlag()))
+	assert.Equal(t, "Excluded", dir.Files[6].GetType())
+
+	usb := dir.Files[7].(*analyze.Dir)
+	assert.Equal(t, uint64(2065), usb.Dev)
+	assert.Equal(t, uint64(2065), usb.Files[0].(*analyze.File).Dev)
+
+	// the second link is not counted
+	dir.UpdateStats(make(fs.HardLinkedItems))
+	assert.Equal(t, ' ', link1.GetFlag())
+	assert.Equal(t, 'H', link2.GetFlag())
+}
+
+func TestNcduExportRoundTrip(t *testing.T) {
+	reference, err := os.ReadFile("../internal/testdata/ncdu.json")
+	assert.Nil(t, err)
+
+	dir, err := ReadAnalysis(bytes.NewBuffer(reference))
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	err = writeJSON(&output, dir)
+	assert.Nil(t, err)
+
+	assert.Equal(t, decodeItems(t, reference), decodeItems(t, output.Bytes()))
+}
+
+func decodeItems(t *testing.T, data []byte) interface{} {
+	var analysis []interface{}
+	err := json.Unmarshal(data, &analysis)
+	assert.Nil(t, err)
+	return analysis[3]
 }
 
 type BrokenInput struct{}
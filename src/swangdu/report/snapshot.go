@@ -0,0 +1,304 @@
+package report
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/ungtb10d/gdu/v5/pkg/storage/itempb"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// snapshotVersion is version of the snapshot format written by gdu
+const snapshotVersion = 1
+
+// maxSnapshotMessageSize limits size of one message so that corrupted snapshot is not read into memory
+const maxSnapshotMessageSize = 1 << 20
+
+// snapshotMagic starts every snapshot file
+var snapshotMagic = []byte("GDUB")
+
+// IsSnapshotFile returns true if the name of the file has extension of the binary snapshot,
+// possibly followed by extension of the compression
+func IsSnapshotFile(name string) bool {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".zst")
+	return strings.HasSuffix(name, ".gdub")
+}
+
+// WriteSnapshot writes the analyzed tree as binary snapshot.
+// The snapshot consists of magic bytes followed by length-delimited protobuf messages:
+// the header and Dir message for every item of the tree in depth-first order.
+// Files are written without item count, directories are followed by all the items in their subtree.
+func WriteSnapshot(w io.Writer, dir fs.Item) error {
+	if _, err := w.Write(snapshotMagic); err != nil {
+		return err
+	}
+
+	host, _ := os.Hostname()
+	writer := &snapshotWriter{output: w}
+	err := writer.write(&itempb.Header{
+		Version:    snapshotVersion,
+		GduVersion: build.Version,
+		Root:       dir.GetPath(),
+		Host:       host,
+		Timestamp:  timestamppb.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return writer.writeItem(dir, 0, true)
+}
+
+type snapshotWriter struct {
+	output io.Writer
+	buff   []byte
+}
+
+// writeItem writes the item and its subtree,
+// device number is written only if it differs from the device of the parent
+func (s *snapshotWriter) writeItem(item fs.Item, parentDev uint64, topLevel bool) error {
+	file := getFile(item)
+	record := &itempb.Dir{
+		Item: &itempb.Item{
+			Path:       item.GetName(),
+			Size:       item.GetSize(),
+			Usage:      item.GetUsage(),
+			Mli:        int64(item.GetMultiLinkedInode()),
+			Mtime:      createTimestamp(item.GetMtime()),
+			Atime:      createTimestamp(item.GetAtime()),
+			Ctime:      createTimestamp(item.GetCtime()),
+			Ino:        file.Ino,
+			Nlink:      file.Nlink,
+			Uid:        file.UID,
+			Gid:        file.GID,
+			Mode:       file.Mode,
+			Target:     file.Target,
+			BrokenLink: file.BrokenLink,
+		},
+	}
+	if topLevel {
+		record.Item.Path = item.GetPath()
+	}
+	if flag := item.GetFlag(); flag != ' ' && flag != 0 {
+		record.Item.Flag = string(flag)
+	}
+	if file.Dev != parentDev {
+		record.Item.Dev = file.Dev
+	}
+	if dir, ok := item.(*analyze.Dir); ok {
+		record.ItemCount = int32(dir.ItemCount)
+		for _, e := range dir.Errors {
+			record.Errors = append(record.Errors, &itempb.ScanError{
+				Name:  e.Name,
+				Op:    e.Op,
+				Errno: int32(e.Errno),
+				Msg:   e.Msg,
+			})
+		}
+	} else if item.IsDir() {
+		record.ItemCount = int32(item.GetItemCount())
+	}
+
+	if err := s.write(record); err != nil {
+		return err
+	}
+	if !item.IsDir() {
+		return nil
+	}
+
+	for _, child := range item.GetFiles() {
+		if err := s.writeItem(child, file.Dev, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getFile returns fields of the item which are not accessible through fs.Item
+func getFile(item fs.Item) *analyze.File {
+	switch f := item.(type) {
+	case *analyze.Dir:
+		return f.File
+	case *analyze.File:
+		return f
+	}
+	return &analyze.File{}
+}
+
+func createTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (s *snapshotWriter) write(m proto.Message) error {
+	var err error
+	s.buff = protowire.AppendVarint(s.buff[:0], uint64(proto.Size(m)))
+	s.buff, err = proto.MarshalOptions{UseCachedSize: true}.MarshalAppend(s.buff, m)
+	if err != nil {
+		return err
+	}
+	_, err = s.output.Write(s.buff)
+	return err
+}
+
+// readSnapshot reads the binary snapshot written by WriteSnapshot
+func (r *AnalysisReader) readSnapshot(input *bufio.Reader) (*analyze.Dir, error) {
+	if _, err := input.Discard(len(snapshotMagic)); err != nil {
+		return nil, err
+	}
+
+	header := &itempb.Header{}
+	if err := r.readMessage(input, header); err != nil {
+		return nil, err
+	}
+	if header.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", header.Version)
+	}
+
+	record := &itempb.Dir{}
+	if err := r.readMessage(input, record); err != nil {
+		return nil, err
+	}
+	if record.ItemCount <= 0 {
+		return nil, errors.New("snapshot does not contain directory")
+	}
+	atomic.AddInt64(&r.itemCount, 1)
+
+	dir := createSnapshotDir(record, 0)
+	if slashPos := strings.LastIndex(dir.Name, "/"); slashPos > -1 {
+		dir.BasePath = dir.Name[:slashPos+1]
+		dir.Name = dir.Name[slashPos+1:]
+	}
+	if err := r.readSnapshotItems(input, dir); err != nil {
+		return nil, err
+	}
+	return dir, nil
+}
+
+// readSnapshotItems reads items of the directory until all the items in its subtree are read
+func (r *AnalysisReader) readSnapshotItems(input *bufio.Reader, dir *analyze.Dir) error {
+	record := &itempb.Dir{}
+	for remaining := dir.ItemCount - 1; remaining > 0; {
+		if err := r.readMessage(input, record); err != nil {
+			return err
+		}
+		if record.ItemCount < 0 || int(record.ItemCount) > remaining {
+			return errors.New("snapshot is corrupted: directory has more items than its parent")
+		}
+		atomic.AddInt64(&r.itemCount, 1)
+
+		if record.ItemCount == 0 {
+			file := createSnapshotFile(record.Item, dir.Dev)
+			file.Parent = dir
+			dir.AddFile(file)
+			remaining--
+			continue
+		}
+
+		subdir := createSnapshotDir(record, dir.Dev)
+		subdir.Parent = dir
+		dir.AddFile(subdir)
+		if err := r.readSnapshotItems(input, subdir); err != nil {
+			return err
+		}
+		remaining -= subdir.ItemCount
+	}
+	return nil
+}
+
+func (r *AnalysisReader) readMessage(input *bufio.Reader, m proto.Message) error {
+	size, err := binary.ReadUvarint(input)
+	if err != nil {
+		return snapshotReadError(err)
+	}
+	if size > maxSnapshotMessageSize {
+		return errors.New("snapshot is corrupted: message is too large")
+	}
+
+	if uint64(cap(r.buff)) < size {
+		r.buff = make([]byte, size)
+	}
+	buff := r.buff[:size]
+	if _, err := io.ReadFull(input, buff); err != nil {
+		return snapshotReadError(err)
+	}
+	if err := proto.Unmarshal(buff, m); err != nil {
+		return fmt.Errorf("snapshot is corrupted: %w", err)
+	}
+	return nil
+}
+
+func snapshotReadError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.New("unexpected end of snapshot")
+	}
+	return err
+}
+
+func createSnapshotDir(record *itempb.Dir, parentDev uint64) *analyze.Dir {
+	dir := &analyze.Dir{
+		File:      createSnapshotFile(record.Item, parentDev),
+		ItemCount: int(record.ItemCount),
+	}
+	for _, e := range record.Errors {
+		dir.Errors = append(dir.Errors, &analyze.ScanError{
+			Name:  e.GetName(),
+			Op:    e.GetOp(),
+			Errno: syscall.Errno(e.GetErrno()),
+			Msg:   e.GetMsg(),
+		})
+	}
+	return dir
+}
+
+// createSnapshotFile creates file from the item,
+// items without device number are on the same device as their parent
+func createSnapshotFile(item *itempb.Item, parentDev uint64) *analyze.File {
+	file := &analyze.File{
+		Name:       item.GetPath(),
+		Size:       item.GetSize(),
+		Usage:      item.GetUsage(),
+		Mli:        uint64(item.GetMli()),
+		Ino:        item.GetIno(),
+		Dev:        item.GetDev(),
+		Nlink:      item.GetNlink(),
+		UID:        item.GetUid(),
+		GID:        item.GetGid(),
+		Mode:       item.GetMode(),
+		Target:     item.GetTarget(),
+		BrokenLink: item.GetBrokenLink(),
+		Flag:       ' ',
+	}
+	if file.Dev == 0 {
+		file.Dev = parentDev
+	}
+	if item.GetFlag() != "" {
+		file.Flag, _ = utf8.DecodeRuneInString(item.GetFlag())
+	}
+	file.Mtime = readTimestamp(item.GetMtime())
+	file.Atime = readTimestamp(item.GetAtime())
+	file.Ctime = readTimestamp(item.GetCtime())
+	return file
+}
+
+func readTimestamp(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Unix(t.GetSeconds(), int64(t.GetNanos()))
+}
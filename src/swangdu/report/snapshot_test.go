@@ -0,0 +1,204 @@
+package report
+
+import (
+	"bytes"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/ungtb10d/gdu/v5/pkg/storage/itempb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSnapshotFile(t *testing.T) {
+	assert.True(t, IsSnapshotFile("snapshot.gdub"))
+	assert.True(t, IsSnapshotFile("snapshot.gdub.gz"))
+	assert.True(t, IsSnapshotFile("snapshot.gdub.zst"))
+	assert.False(t, IsSnapshotFile("snapshot.json"))
+	assert.False(t, IsSnapshotFile("gdub"))
+}
+
+func TestExportSnapshot(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	reportOutput := &bytes.Buffer{}
+
+	ui := CreateExportUI(output, reportOutput, false, false, false, false)
+	ui.SetFormat(FormatSnapshot)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+	assert.True(t, bytes.HasPrefix(reportOutput.Bytes(), snapshotMagic))
+
+	reader := CreateAnalysisReader(reportOutput)
+	dir, err := reader.Read()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "test_dir", dir.GetName())
+	assert.Equal(t, 5, dir.GetItemCount())
+	nested := dir.Files[0].(*analyze.Dir)
+	assert.Equal(t, "nested", nested.GetName())
+	assert.Equal(t, dir, nested.GetParent())
+	assert.Len(t, nested.Files, 2)
+
+	_, itemCount, _ := reader.GetProgress()
+	assert.Equal(t, 5, itemCount)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	mtime := time.Date(2021, 8, 19, 0, 40, 0, 0, time.UTC)
+	dir := createTestTree(5)
+	dir.BasePath = "/home"
+	dir.Files[0].(*analyze.File).Mtime = mtime
+	dir.Files[1].(*analyze.File).Mli = 1234
+	dir.Files[2].(*analyze.File).Flag = 'H'
+	dir.Dev = 2049
+	dir.Errors = []*analyze.ScanError{{Name: "gone", Op: analyze.OpStat, Errno: syscall.ENOENT, Msg: "no such file"}}
+	link := dir.Files[1].(*analyze.File)
+	link.Dev = 2049
+	link.Nlink = 2
+	link.Ino = 1234
+	owned := dir.Files[3].(*analyze.File)
+	owned.UID = 1000
+	owned.GID = 100
+	owned.Mode = 0100644
+	owned.Atime = mtime.Add(time.Hour)
+	owned.Ctime = mtime.Add(2 * time.Hour)
+	symlink := dir.Files[4].(*analyze.File)
+	symlink.Flag = '@'
+	symlink.Target = "missing"
+	symlink.BrokenLink = true
+	sub := dir.Files[len(dir.Files)-1].(*analyze.Dir)
+	sub.Dev = 2065
+
+	var buff bytes.Buffer
+	err := WriteSnapshot(&buff, dir)
+	assert.Nil(t, err)
+
+	read, err := ReadAnalysis(&buff)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "/home/dir", read.GetPath())
+	assert.Equal(t, dir.GetItemCount(), read.GetItemCount())
+	assert.Equal(t, dir.GetUsage(), read.GetUsage())
+	assert.True(t, mtime.Equal(read.Files[0].GetMtime()))
+	assert.Equal(t, uint64(1234), read.Files[1].GetMultiLinkedInode())
+	assert.Equal(t, 'H', read.Files[2].GetFlag())
+	assert.Equal(t, ' ', read.Files[3].GetFlag())
+
+	assert.Equal(t, uint64(2049), read.Dev)
+	assert.Equal(t, dir.Errors, read.Errors)
+	readLink := read.Files[1].(*analyze.File)
+	assert.Equal(t, uint64(2049), readLink.Dev)
+	assert.Equal(t, uint32(2), readLink.Nlink)
+	assert.Equal(t, uint64(1234), readLink.GetInode())
+	readOwned := read.Files[3].(*analyze.File)
+	assert.Equal(t, uint32(1000), readOwned.GetUID())
+	assert.Equal(t, uint32(100), readOwned.GetGID())
+	assert.Equal(t, uint32(0100644), readOwned.Mode)
+	assert.True(t, owned.Atime.Equal(readOwned.GetAtime()))
+	assert.True(t, owned.Ctime.Equal(readOwned.GetCtime()))
+	readSymlink := read.Files[4].(*analyze.File)
+	assert.Equal(t, "missing", readSymlink.GetTarget())
+	assert.True(t, analyze.IsBrokenSymlink(readSymlink))
+	assert.Equal(t, '@', readSymlink.GetFlag())
+
+	readSub := read.Files[len(read.Files)-1].(*analyze.Dir)
+	assert.Equal(t, "/home/dir/sub", readSub.GetPath())
+	assert.Equal(t, uint64(2065), readSub.Dev)
+	assert.Equal(t, uint64(2065), readSub.Files[0].(*analyze.File).Dev)
+	assert.Equal(t, "file2", readSub.Files[2].GetName())
+	assert.Equal(t, int64(2), readSub.Files[2].GetSize())
+}
+
+func TestReadTruncatedSnapshot(t *testing.T) {
+	var buff bytes.Buffer
+	err := WriteSnapshot(&buff, createTestTree(3))
+	assert.Nil(t, err)
+
+	_, err = ReadAnalysis(bytes.NewBuffer(buff.Bytes()[:buff.Len()-3]))
+	assert.EqualError(t, err, "unexpected end of snapshot")
+}
+
+func TestReadSnapshotOfNewerVersion(t *testing.T) {
+	var buff bytes.Buffer
+	buff.Write(snapshotMagic)
+	writer := &snapshotWriter{output: &buff}
+	err := writer.write(&itempb.Header{Version: snapshotVersion + 1})
+	assert.Nil(t, err)
+
+	_, err = ReadAnalysis(&buff)
+	assert.EqualError(t, err, "unsupported snapshot version 2")
+}
+
+func TestReadCorruptedSnapshot(t *testing.T) {
+	var buff bytes.Buffer
+	buff.Write(snapshotMagic)
+	writer := &snapshotWriter{output: &buff}
+	assert.Nil(t, writer.write(&itempb.Header{Version: snapshotVersion}))
+	assert.Nil(t, writer.write(&itempb.Dir{Item: &itempb.Item{Path: "/dir"}, ItemCount: 2}))
+	assert.Nil(t, writer.write(&itempb.Dir{Item: &itempb.Item{Path: "sub"}, ItemCount: 5}))
+
+	_, err := ReadAnalysis(&buff)
+	assert.ErrorContains(t, err, "snapshot is corrupted")
+}
+
+func BenchmarkReadJSON(b *testing.B) {
+	var buff bytes.Buffer
+	if err := writeJSON(&buff, createTestTree(100000)); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadAnalysis(bytes.NewReader(buff.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(buff.Len()), "size")
+}
+
+func BenchmarkReadSnapshot(b *testing.B) {
+	var buff bytes.Buffer
+	if err := WriteSnapshot(&buff, createTestTree(100000)); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadAnalysis(bytes.NewReader(buff.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(buff.Len()), "size")
+}
+
+// createTestTree creates directory with given number of files and subdirectory with the same files
+func createTestTree(files int) *analyze.Dir {
+	dir := &analyze.Dir{
+		File: &analyze.File{Name: "dir", Flag: ' '},
+	}
+	sub := &analyze.Dir{
+		File: &analyze.File{Name: "sub", Flag: ' ', Parent: dir},
+	}
+	for _, d := range []*analyze.Dir{dir, sub} {
+		for i := 0; i < files; i++ {
+			d.AddFile(&analyze.File{
+				Name:   "file" + strconv.Itoa(i),
+				Size:   int64(i),
+				Usage:  4096,
+				Mtime:  time.Unix(1629333600+int64(i), 0),
+				Flag:   ' ',
+				Parent: d,
+			})
+		}
+	}
+	dir.AddFile(sub)
+	dir.UpdateStats(make(fs.HardLinkedItems))
+	return dir
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"runtime"
 	"sort"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/ungtb10d/gdu/v5/pkg/device"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/ungtb10d/gdu/v5/pkg/owner"
 	"github.com/ungtb10d/gdu/v5/report"
 	"github.com/fatih/color"
 )
@@ -20,11 +22,19 @@ import (
 // UI struct
 type UI struct {
 	*common.UI
-	output    io.Writer
-	red       *color.Color
-	orange    *color.Color
-	blue      *color.Color
-	summarize bool
+	output      io.Writer
+	errOutput   io.Writer
+	red         *color.Color
+	orange      *color.Color
+	blue        *color.Color
+	summarize   bool
+	byOwner     bool
+	byType      bool
+	duplicates  bool
+	brokenLinks bool
+	topFiles    int
+	olderThan   time.Duration
+	resolver    *owner.Resolver
 }
 
 var progressRunes = []rune(`⠇⠏⠋⠙⠹⠸⠼⠴⠦⠧`)
@@ -51,7 +61,9 @@ func CreateStdoutUI(
 			UseSIPrefix:      useSIPrefix,
 		},
 		output:    output,
+		errOutput: os.Stderr,
 		summarize: summarize,
+		resolver:  owner.DefaultResolver,
 	}
 
 	ui.red = color.New(color.FgRed).Add(color.Bold)
@@ -65,6 +77,36 @@ func CreateStdoutUI(
 	return ui
 }
 
+// SetShowByOwner sets if usage aggregated by users and groups should be shown instead of the dir content
+func (ui *UI) SetShowByOwner(value bool) {
+	ui.byOwner = value
+}
+
+// SetShowByType sets if usage aggregated by file categories and extensions should be shown instead of the dir content
+func (ui *UI) SetShowByType(value bool) {
+	ui.byType = value
+}
+
+// SetShowDuplicates sets if sets of duplicate files should be shown instead of the dir content
+func (ui *UI) SetShowDuplicates(value bool) {
+	ui.duplicates = value
+}
+
+// SetShowBrokenLinks sets if broken symlinks should be listed instead of the dir content
+func (ui *UI) SetShowBrokenLinks(value bool) {
+	ui.brokenLinks = value
+}
+
+// SetTopFiles sets number of the largest files in the whole tree which should be listed instead of the dir content
+func (ui *UI) SetTopFiles(count int) {
+	ui.topFiles = count
+}
+
+// SetOlderThan sets that only items not modified for given duration are shown
+func (ui *UI) SetOlderThan(age time.Duration) {
+	ui.olderThan = age
+}
+
 // StartUILoop stub
 func (ui *UI) StartUILoop() error {
 	return nil
@@ -152,23 +194,156 @@ func (ui *UI) AnalyzePath(path string, _ fs.Item) error {
 
 	wait.Wait()
 
-	if ui.summarize {
+	ui.showReport(dir)
+
+	return nil
+}
+
+// showReport prints the analyzed directory in the requested form and summary of scan errors
+func (ui *UI) showReport(dir fs.Item) {
+	switch {
+	case ui.summarize:
 		ui.printTotalItem(dir)
-	} else {
+	case ui.byOwner:
+		ui.showOwners(dir)
+	case ui.byType:
+		ui.showFileTypes(dir)
+	case ui.duplicates:
+		ui.showDuplicates(dir)
+	case ui.brokenLinks:
+		ui.showBrokenLinks(dir)
+	case ui.topFiles > 0:
+		ui.showTopFiles(dir)
+	default:
 		ui.showDir(dir)
 	}
-
-	return nil
+	ui.showErrorsSummary(dir)
 }
 
 func (ui *UI) showDir(dir fs.Item) {
 	sort.Sort(dir.GetFiles())
 
+	now := time.Now()
 	for _, file := range dir.GetFiles() {
+		if ui.olderThan > 0 && !analyze.IsOlderThan(file, ui.olderThan, now) {
+			continue
+		}
 		ui.printItem(file)
 	}
 }
 
+func (ui *UI) showOwners(dir fs.Item) {
+	users, groups := analyze.GetUsageByOwner(dir)
+	if ui.ShowApparentSize {
+		sort.Sort(analyze.OwnersByApparentSize(users))
+		sort.Sort(analyze.OwnersByApparentSize(groups))
+	}
+
+	fmt.Fprintln(ui.output, "Users:")
+	for _, user := range users {
+		ui.printSummaryItem(ownerName(user, ui.resolver.UserName), user.Size, user.Usage, user.ItemCount)
+	}
+	fmt.Fprintln(ui.output, "Groups:")
+	for _, group := range groups {
+		ui.printSummaryItem(ownerName(group, ui.resolver.GroupName), group.Size, group.Usage, group.ItemCount)
+	}
+}
+
+func ownerName(owner *analyze.OwnerUsage, getName func(uint32) string) string {
+	if owner.Unknown {
+		return analyze.UnknownOwner
+	}
+	return getName(owner.ID)
+}
+
+func (ui *UI) showFileTypes(dir fs.Item) {
+	categories, extensions := analyze.GetUsageByType(dir)
+	if ui.ShowApparentSize {
+		sort.Sort(analyze.TypesByApparentSize(categories))
+		sort.Sort(analyze.TypesByApparentSize(extensions))
+	}
+
+	fmt.Fprintln(ui.output, "Categories:")
+	for _, category := range categories {
+		ui.printSummaryItem(category.Name, category.Size, category.Usage, category.FileCount)
+	}
+	fmt.Fprintln(ui.output, "Extensions:")
+	for _, ext := range extensions {
+		ui.printSummaryItem(ext.Name, ext.Size, ext.Usage, ext.FileCount)
+	}
+}
+
+func (ui *UI) showDuplicates(dir fs.Item) {
+	var total int64
+	for _, set := range analyze.FindDuplicates(dir) {
+		total += set.Reclaimable()
+		fmt.Fprintf(
+			ui.output,
+			"%s reclaimable, %d copies of %s:\n",
+			ui.formatSize(set.Reclaimable()),
+			len(set.Files),
+			ui.formatSize(set.Size),
+		)
+		for _, file := range set.Files {
+			fmt.Fprintf(ui.output, "    %s\n", file.GetPath())
+		}
+	}
+	fmt.Fprintf(ui.output, "Total reclaimable: %s\n", ui.formatSize(total))
+}
+
+func (ui *UI) showBrokenLinks(dir fs.Item) {
+	for _, link := range analyze.GetBrokenSymlinks(dir) {
+		fmt.Fprintf(ui.output, "%s -> %s\n", link.GetPath(), link.GetTarget())
+	}
+}
+
+func (ui *UI) showTopFiles(dir fs.Item) {
+	var lineFormat string
+	if ui.UseColors {
+		lineFormat = "%20s %s\n"
+	} else {
+		lineFormat = "%9s %s\n"
+	}
+
+	for _, file := range analyze.GetLargestFiles(dir, ui.topFiles, ui.ShowApparentSize, nil) {
+		size := file.GetUsage()
+		if ui.ShowApparentSize {
+			size = file.GetSize()
+		}
+		fmt.Fprintf(ui.output, lineFormat, ui.formatSize(size), file.GetPath())
+	}
+}
+
+// showErrorsSummary prints counts of errors which occurred during the analysis to the error output
+func (ui *UI) showErrorsSummary(dir fs.Item) {
+	for _, summary := range analyze.SummarizeScanErrors(analyze.GetScanErrors(dir)) {
+		fmt.Fprintln(ui.errOutput, summary.String())
+	}
+}
+
+// printSummaryItem prints usage aggregated over multiple items together with their count
+func (ui *UI) printSummaryItem(name string, apparentSize int64, usage int64, count int) {
+	var lineFormat string
+	if ui.UseColors {
+		lineFormat = "%20s %11s %s\n"
+	} else {
+		lineFormat = "%9s %11s %s\n"
+	}
+
+	size := usage
+	if ui.ShowApparentSize {
+		size = apparentSize
+	}
+
+	fmt.Fprintf(
+		ui.output,
+		lineFormat,
+		ui.formatSize(size),
+		common.FormatNumber(int64(count)),
+		name,
+	)
+}
+
 func (ui *UI) printTotalItem(file fs.Item) {
 	var lineFormat string
 	if ui.UseColors {
@@ -222,8 +397,9 @@ func (ui *UI) printItem(file fs.Item) {
 	}
 }
 
-// ReadAnalysis reads analysis report from JSON file
-func (ui *UI) ReadAnalysis(input io.Reader) error {
+// ReadAnalysis reads analysis report from JSON file,
+// analyses read from several files are merged into one tree
+func (ui *UI) ReadAnalysis(inputs ...io.Reader) error {
 	var (
 		dir      *analyze.Dir
 		wait     sync.WaitGroup
@@ -231,19 +407,21 @@ func (ui *UI) ReadAnalysis(input io.Reader) error {
 		doneChan chan struct{}
 	)
 
+	reader := report.CreateMergingAnalysisReader(inputs, ui.KeepInputPaths)
+
 	if ui.ShowProgress {
 		wait.Add(1)
 		doneChan = make(chan struct{})
 		go func() {
 			defer wait.Done()
-			ui.showReadingProgress(doneChan)
+			ui.showReadingProgress(reader, doneChan)
 		}()
 	}
 
 	wait.Add(1)
 	go func() {
 		defer wait.Done()
-		dir, err = report.ReadAnalysis(input)
+		dir, err = reader.Read()
 		if err != nil {
 			if ui.ShowProgress {
 				doneChan <- struct{}{}
@@ -265,14 +443,14 @@ func (ui *UI) ReadAnalysis(input io.Reader) error {
 		return err
 	}
 
-	ui.showDir(dir)
+	ui.showReport(dir)
 
 	return nil
 }
 
-func (ui *UI) showReadingProgress(doneChan chan struct{}) {
+func (ui *UI) showReadingProgress(reader *report.AnalysisReader, doneChan chan struct{}) {
 	emptyRow := "\r"
-	for j := 0; j < 40; j++ {
+	for j := 0; j < 100; j++ {
 		emptyRow += " "
 	}
 
@@ -288,7 +466,14 @@ func (ui *UI) showReadingProgress(doneChan chan struct{}) {
 		}
 
 		fmt.Fprintf(ui.output, "\r %s ", string(progressRunes[i]))
-		fmt.Fprint(ui.output, "Reading analysis from file...")
+		bytesRead, itemCount, totalSize := reader.GetProgress()
+		fmt.Fprint(ui.output, "Reading analysis from file... Total items: "+
+			ui.red.Sprint(common.FormatNumber(int64(itemCount)))+
+			" read: "+
+			ui.formatSize(bytesRead))
+		if totalSize > 0 {
+			fmt.Fprint(ui.output, " of "+ui.formatSize(totalSize))
+		}
 
 		time.Sleep(100 * time.Millisecond)
 		i++
@@ -2,8 +2,10 @@ package stdout
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -11,6 +13,8 @@ import (
 	"github.com/ungtb10d/gdu/v5/internal/testdev"
 	"github.com/ungtb10d/gdu/v5/internal/testdir"
 	"github.com/ungtb10d/gdu/v5/pkg/device"
+	"github.com/ungtb10d/gdu/v5/pkg/owner"
+	"github.com/ungtb10d/gdu/v5/report"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +39,20 @@ func TestAnalyzePath(t *testing.T) {
 	assert.Contains(t, output.String(), "nested")
 }
 
+func TestAnalyzePathWithExcludedDir(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := &bytes.Buffer{}
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, true, false)
+	ui.SetIgnoreDirPaths([]string{"test_dir/nested/subnested"})
+	err := ui.AnalyzePath("test_dir/nested", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "<       0 B subnested\n")
+}
+
 func TestShowSummary(t *testing.T) {
 	fin := testdir.CreateTestDir()
 	defer fin()
@@ -69,6 +87,132 @@ func TestShowSummaryBw(t *testing.T) {
 	assert.Contains(t, output.String(), "test_dir")
 }
 
+func TestShowByOwner(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.resolver = &owner.Resolver{PasswdPath: "/xxx", GroupPath: "/xxx"}
+	ui.SetShowByOwner(true)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "Users:")
+	assert.Contains(t, output.String(), "Groups:")
+	assert.Contains(t, output.String(), fmt.Sprintf(" %d\n", os.Getuid()))
+	assert.NotContains(t, output.String(), "nested")
+}
+
+func TestShowByOwnerApparentSizeColors(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, true, false, true, false, false, false, false)
+	ui.SetShowByOwner(true)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "Users:")
+	assert.Contains(t, output.String(), "Groups:")
+}
+
+func TestShowByType(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, false, false, true, false, false, false, false)
+	ui.SetShowByType(true)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "Categories:")
+	assert.Contains(t, output.String(), "Extensions:")
+	assert.Contains(t, output.String(), "7 B           2 Other")
+	assert.NotContains(t, output.String(), "nested")
+}
+
+func TestShowDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.WriteFile("test_dir/nested/dup", []byte("hello"), 0600)
+	assert.Nil(t, err)
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.SetShowDuplicates(true)
+	err = ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "2 copies of 5 B:")
+	assert.Contains(t, output.String(), "    test_dir/nested/dup\n")
+	assert.Contains(t, output.String(), "    test_dir/nested/subnested/file\n")
+	assert.Contains(t, output.String(), "Total reclaimable:")
+	assert.NotContains(t, output.String(), "file2")
+}
+
+func TestShowBrokenLinks(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.Symlink("missing", "test_dir/nested/dangling")
+	assert.Nil(t, err)
+	err = os.Symlink("file2", "test_dir/nested/valid")
+	assert.Nil(t, err)
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.SetShowBrokenLinks(true)
+	err = ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "test_dir/nested/dangling -> missing\n")
+	assert.NotContains(t, output.String(), "valid")
+}
+
+func TestShowTopFiles(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+
+	ui := CreateStdoutUI(output, false, false, true, false, false, false, false)
+	ui.SetTopFiles(1)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, output.String(), "5 B test_dir/nested/subnested/file\n")
+	assert.NotContains(t, output.String(), "file2")
+}
+
+func TestShowOlderThan(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	buff := make([]byte, 10)
+	output := bytes.NewBuffer(buff)
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.SetOlderThan(24 * time.Hour)
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	assert.NotContains(t, output.String(), "nested")
+}
+
 func TestAnalyzeSubdir(t *testing.T) {
 	fin := testdir.CreateTestDir()
 	defer fin()
@@ -174,6 +318,65 @@ func TestReadAnalysisBw(t *testing.T) {
 	assert.Contains(t, output.String(), "main.go")
 }
 
+func TestReadAnalysisWithErrors(t *testing.T) {
+	input := bytes.NewBufferString(`[1,2,{"progname":"gdu"},
+		[{"name":"/home/xxx"},
+		[{"name":"a","read_error":true,"errors":[{"name":"","op":"readdir","errno":13,"msg":"permission denied"}]}],
+		[{"name":"b","read_error":true,"errors":[{"name":"","op":"readdir","errno":13,"msg":"permission denied"}]}],
+		[{"name":"c","errors":[{"name":"gone","op":"stat","errno":2,"msg":"no such file or directory"}]}]]]`)
+
+	output := bytes.NewBuffer(make([]byte, 10))
+	errOutput := &bytes.Buffer{}
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.errOutput = errOutput
+	err := ui.ReadAnalysis(input)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		"2 directories unreadable: permission denied\n1 entry inaccessible: no such file or directory\n",
+		errOutput.String(),
+	)
+	assert.NotContains(t, output.String(), "unreadable")
+}
+
+func TestReadAnalysisByOwner(t *testing.T) {
+	input := bytes.NewBufferString(`[1,2,{"progname":"gdu"},
+		[{"name":"/home/xxx","uid":1234,"gid":5678,"mode":16877},
+		{"name":"file","asize":5,"dsize":8,"uid":1234,"gid":5678,"mode":33188}]]`)
+
+	output := &bytes.Buffer{}
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.resolver = &owner.Resolver{PasswdPath: "/xxx", GroupPath: "/xxx"}
+	ui.SetShowByOwner(true)
+	err := ui.ReadAnalysis(input)
+
+	assert.Nil(t, err)
+	assert.Contains(t, output.String(), "Users:")
+	assert.Contains(t, output.String(), " 1234\n")
+	assert.Contains(t, output.String(), " 5678\n")
+	assert.NotContains(t, output.String(), " file\n")
+}
+
+func TestReadAnalysisTopFiles(t *testing.T) {
+	input := bytes.NewBufferString(`[1,2,{"progname":"gdu"},
+		[{"name":"/home/xxx"},
+		{"name":"small","asize":5,"dsize":8},
+		[{"name":"sub"},{"name":"big","asize":5000,"dsize":8192}]]]`)
+
+	output := &bytes.Buffer{}
+
+	ui := CreateStdoutUI(output, false, false, false, false, false, false, false)
+	ui.SetTopFiles(1)
+	err := ui.ReadAnalysis(input)
+
+	assert.Nil(t, err)
+	assert.Contains(t, output.String(), "sub/big")
+	assert.NotContains(t, output.String(), "small")
+}
+
 func TestReadAnalysisWithWrongFile(t *testing.T) {
 	input, err := os.OpenFile("../internal/testdata/wrong.json", os.O_RDONLY, 0644)
 	assert.Nil(t, err)
@@ -234,3 +437,26 @@ func getDevicesInfoMock() device.DevicesInfoGetter {
 	mock.Devices = []*device.Device{item}
 	return mock
 }
+
+func TestShowReadingProgress(t *testing.T) {
+	input, err := os.OpenFile("../internal/testdata/test.json", os.O_RDONLY, 0644)
+	assert.Nil(t, err)
+	defer input.Close()
+
+	output := bytes.NewBuffer(make([]byte, 10))
+
+	ui := CreateStdoutUI(output, false, true, false, false, false, false, false)
+	reader := report.CreateAnalysisReader(input)
+	_, err = reader.Read()
+	assert.Nil(t, err)
+
+	doneChan := make(chan struct{})
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		doneChan <- struct{}{}
+	}()
+	ui.showReadingProgress(reader, doneChan)
+
+	assert.Contains(t, output.String(), "Reading analysis from file... Total items: ")
+	assert.Contains(t, output.String(), " of ")
+}
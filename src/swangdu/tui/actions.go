@@ -66,6 +66,7 @@ func (ui *UI) AnalyzePath(path string, parentDir fs.Item) error {
 		} else {
 			ui.topDirPath = path
 			ui.topDir = currentDir
+			ui.backHistory, ui.forwardHistory = nil, nil
 		}
 
 		ui.topDir.UpdateStats(ui.linkedItems)
@@ -74,6 +75,9 @@ func (ui *UI) AnalyzePath(path string, parentDir fs.Item) error {
 			ui.currentDir = currentDir
 			ui.showDir()
 			ui.pages.RemovePage("progress")
+			if parentDir == nil {
+				ui.openStartAt()
+			}
 		})
 
 		if ui.done != nil {
@@ -84,8 +88,9 @@ func (ui *UI) AnalyzePath(path string, parentDir fs.Item) error {
 	return nil
 }
 
-// ReadAnalysis reads analysis report from JSON file
-func (ui *UI) ReadAnalysis(input io.Reader) error {
+// ReadAnalysis reads analysis report from JSON file,
+// analyses read from several files are merged into one tree
+func (ui *UI) ReadAnalysis(inputs ...io.Reader) error {
 	ui.progress = tview.NewTextView().SetText("Reading analysis from file...")
 	ui.progress.SetBorder(true).SetBorderPadding(2, 2, 2, 2)
 	ui.progress.SetTitle(" Reading... ")
@@ -101,9 +106,14 @@ func (ui *UI) ReadAnalysis(input io.Reader) error {
 
 	ui.pages.AddPage("progress", flex, true, true)
 
+	reader := report.CreateMergingAnalysisReader(inputs, ui.KeepInputPaths)
+	readDone := make(chan struct{})
+	go ui.updateReadProgress(reader, readDone)
+
 	go func() {
 		var err error
-		ui.currentDir, err = report.ReadAnalysis(input)
+		ui.currentDir, err = reader.Read()
+		close(readDone)
 		if err != nil {
 			ui.app.QueueUpdateDraw(func() {
 				ui.pages.RemovePage("progress")
@@ -118,6 +128,7 @@ func (ui *UI) ReadAnalysis(input io.Reader) error {
 
 		ui.topDirPath = ui.currentDir.GetPath()
 		ui.topDir = ui.currentDir
+		ui.backHistory, ui.forwardHistory = nil, nil
 
 		links := make(fs.HardLinkedItems, 10)
 		ui.topDir.UpdateStats(links)
@@ -125,6 +136,7 @@ func (ui *UI) ReadAnalysis(input io.Reader) error {
 		ui.app.QueueUpdateDraw(func() {
 			ui.showDir()
 			ui.pages.RemovePage("progress")
+			ui.openStartAt()
 		})
 
 		if ui.done != nil {
@@ -160,6 +172,9 @@ func (ui *UI) deleteSelected(shouldEmpty bool) {
 	if shouldEmpty && selectedItem.IsDir() {
 		currentDir = selectedItem.(*analyze.Dir)
 		for _, file := range currentDir.GetFiles() {
+			if isExcluded(file) {
+				continue
+			}
 			deleteItems = append(deleteItems, file)
 		}
 	} else {
@@ -304,7 +319,7 @@ func (ui *UI) showInfo() {
 		numberColor = "[::b]"
 	}
 
-	linesCount := 12
+	linesCount := 13
 
 	text := tview.NewTextView().SetDynamicColors(true)
 	text.SetBorder(true).SetBorderPadding(2, 2, 2, 2)
@@ -317,7 +332,26 @@ func (ui *UI) showInfo() {
 	content += tview.Escape(
 		strings.TrimPrefix(selectedFile.GetPath(), build.RootPathPrefix),
 	) + "\n"
-	content += "[::b]Type:[::-] " + selectedFile.GetType() + "\n\n"
+	content += "[::b]Type:[::-] " + selectedFile.GetType() + "\n"
+	if selectedFile.GetTarget() != "" {
+		linesCount++
+		content += "[::b]Target:[::-] " + tview.Escape(selectedFile.GetTarget())
+		if analyze.IsBrokenSymlink(selectedFile) {
+			content += " (broken)"
+		}
+		content += "\n"
+	}
+	if analyze.HasOwner(selectedFile) {
+		content += "[::b]Owner:[::-] "
+		content += tview.Escape(ui.resolver.UserName(selectedFile.GetUID()))
+		content += fmt.Sprintf(" (%d), ", selectedFile.GetUID())
+		content += "[::b]Group:[::-] "
+		content += tview.Escape(ui.resolver.GroupName(selectedFile.GetGID()))
+		content += fmt.Sprintf(" (%d)", selectedFile.GetGID()) + "\n\n"
+	} else {
+		content += "[::b]Owner:[::-] " + analyze.UnknownOwner + ", "
+		content += "[::b]Group:[::-] " + analyze.UnknownOwner + "\n\n"
+	}
 
 	content += "   [::b]Disk usage:[::-] "
 	content += numberColor + ui.formatSize(selectedFile.GetUsage(), false, true)
@@ -327,7 +361,7 @@ func (ui *UI) showInfo() {
 	content += fmt.Sprintf(" (%s%d[-::] B)", numberColor, selectedFile.GetSize()) + "\n"
 
 	if selectedFile.GetMultiLinkedInode() > 0 {
-		linkedItems := ui.linkedItems[selectedFile.GetMultiLinkedInode()]
+		linkedItems := ui.linkedItems[selectedFile.GetMultiLinkedID()]
 		linesCount += 2 + len(linkedItems)
 		content += "\nHard-linked files:\n"
 		for _, linkedItem := range linkedItems {
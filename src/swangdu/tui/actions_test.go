@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ungtb10d/gdu/v5/internal/testanalyze"
 	"github.com/ungtb10d/gdu/v5/internal/testapp"
 	"github.com/ungtb10d/gdu/v5/internal/testdir"
 	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/ungtb10d/gdu/v5/report"
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -153,6 +156,32 @@ func TestReadAnalysis(t *testing.T) {
 	assert.Equal(t, "gdu", ui.currentDir.GetName())
 }
 
+func TestUpdateReadProgress(t *testing.T) {
+	simScreen := testapp.CreateSimScreen(50, 50)
+	defer simScreen.Fini()
+
+	app := testapp.CreateMockedApp(true)
+	ui := CreateUI(app, simScreen, &bytes.Buffer{}, true, true, true, false, false)
+	ui.progress = tview.NewTextView()
+
+	reader := report.CreateAnalysisReader(bytes.NewBufferString(`[1,2,3,[{"name":"xxx"},{"name":"file"}]]`))
+	_, err := reader.Read()
+	assert.Nil(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		close(done)
+	}()
+	ui.updateReadProgress(reader, done)
+
+	for _, f := range ui.app.(*testapp.MockedApp).UpdateDraws {
+		f()
+	}
+	assert.Contains(t, ui.progress.GetText(true), "Total items: [red:black:b]2")
+	assert.Contains(t, ui.progress.GetText(true), "read: [red:black:b]40")
+}
+
 func TestReadAnalysisWithWrongFile(t *testing.T) {
 	simScreen := testapp.CreateSimScreen(50, 50)
 	defer simScreen.Fini()
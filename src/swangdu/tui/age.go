@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showAges shows disk usage of files in the current directory bucketed by age.
+// Selecting a bucket filters the directory listing to items older than the bucket.
+func (ui *UI) showAges() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	buckets := analyze.GetUsageByAge(ui.currentDir, time.Now())
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, "Last modified:")
+	for _, bucket := range buckets {
+		cell := tview.NewTableCell(ui.formatSummaryRow(
+			bucket.Name,
+			bucket.Usage,
+			bucket.Size,
+			bucket.FileCount,
+			ui.currentDir.GetUsage(),
+			ui.currentDir.GetSize(),
+		))
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(bucket)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	table.Select(1, 0)
+
+	table.SetSelectedFunc(func(row, column int) {
+		if bucket, ok := table.GetCell(row, column).GetReference().(*analyze.AgeUsage); ok {
+			ui.closeView()
+			ui.setOlderThan(bucket.MinAge)
+		}
+	})
+
+	ui.showView(
+		"ages",
+		"Usage by age: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
+
+// setOlderThan filters the directory listing to items not modified for given duration, zero turns the filter off
+func (ui *UI) setOlderThan(age time.Duration) {
+	ui.olderThan = age
+	if ui.currentDir != nil {
+		ui.showDir()
+	}
+}
+
+// showOlderThanInput asks for number of days for filtering items not modified since then
+func (ui *UI) showOlderThanInput() *tview.InputField {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	input := ui.showInputPage("olderthan", "Filter by age", "Show items not modified in days (empty for all): ", func(text string) {
+		days, err := strconv.Atoi(text)
+		if err != nil || days < 0 {
+			days = 0
+		}
+		ui.setOlderThan(time.Duration(days) * analyze.Day)
+	})
+	input.SetAcceptanceFunc(tview.InputFieldInteger)
+	if ui.olderThan > 0 {
+		input.SetText(strconv.Itoa(int(ui.olderThan / analyze.Day)))
+	}
+	return input
+}
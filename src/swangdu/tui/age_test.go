@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowAges(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, false, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'A', 0))
+
+	assert.Equal(t, "ages", ui.activeView)
+	assert.True(t, ui.pages.HasPage("ages"))
+}
+
+func TestShowAgesContent(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	table := ui.showAges()
+
+	assert.Equal(t, 6, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "Last modified:")
+	assert.Contains(t, table.GetCell(1, 0).Text, "< 1 day")
+	assert.Contains(t, table.GetCell(5, 0).Text, "> 1 year")
+	assert.Contains(t, table.GetCell(5, 0).Text, "1[-::] > 1 year")
+}
+
+func TestShowAgesWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showAges())
+	assert.Nil(t, ui.showOlderThanInput())
+}
+
+func TestSelectAgeBucket(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	table := ui.showAges()
+	table.Select(5, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), func(p tview.Primitive) {})
+
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, 365*analyze.Day, ui.olderThan)
+	assert.Equal(t, 4, ui.table.GetRowCount())
+	assert.Contains(t, ui.footerLabel.GetText(true), "Older than: 365 days")
+
+	table = ui.showAges()
+	table.Select(1, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), func(p tview.Primitive) {})
+
+	assert.Equal(t, analyze.Day*0, ui.olderThan)
+	assert.NotContains(t, ui.footerLabel.GetText(true), "Older than")
+}
+
+func TestOlderThanInput(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'T', 0))
+	assert.True(t, ui.pages.HasPage("olderthan"))
+
+	// keys are passed to the input
+	assert.NotNil(t, ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'q', 0)))
+
+	input := ui.showOlderThanInput()
+	input.SetText("100000")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), func(p tview.Primitive) {})
+
+	assert.False(t, ui.pages.HasPage("olderthan"))
+	assert.Equal(t, 100000*analyze.Day, ui.olderThan)
+	assert.Equal(t, 0, ui.table.GetRowCount())
+
+	input = ui.showOlderThanInput()
+	assert.Equal(t, "100000", input.GetText())
+	input.SetText("")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), func(p tview.Primitive) {})
+
+	assert.Equal(t, analyze.Day*0, ui.olderThan)
+	assert.Equal(t, 4, ui.table.GetRowCount())
+}
+
+func TestOlderThanInputCanceled(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	input := ui.showOlderThanInput()
+	input.SetText("10")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEsc, 0, 0), func(p tview.Primitive) {})
+
+	assert.False(t, ui.pages.HasPage("olderthan"))
+	assert.Equal(t, analyze.Day*0, ui.olderThan)
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// bookmark is named location stored in the bookmarks file
+type bookmark struct {
+	name string
+	path string
+}
+
+// getBookmarksPath returns path of the file with bookmarks in the user's config directory
+func getBookmarksPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gdu", "bookmarks")
+}
+
+// loadBookmarks reads bookmarks stored one per line as name and absolute path separated by tab
+func loadBookmarks(path string) ([]*bookmark, error) {
+	bookmarks := make([]*bookmark, 0)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return bookmarks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		bookmarks = append(bookmarks, &bookmark{name: parts[0], path: parts[1]})
+	}
+	return bookmarks, scanner.Err()
+}
+
+func saveBookmarks(path string, bookmarks []*bookmark) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	for _, b := range bookmarks {
+		content.WriteString(b.name + "\t" + b.path + "\n")
+	}
+	return os.WriteFile(path, []byte(content.String()), 0o600)
+}
+
+// showAddBookmarkInput asks for name of the bookmark of the current directory
+func (ui *UI) showAddBookmarkInput() *tview.InputField {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	input := ui.showInputPage("bookmark", "Add bookmark", "Bookmark name: ", func(name string) {
+		if name == "" {
+			return
+		}
+		if err := ui.addBookmark(name); err != nil {
+			ui.showErr("Error saving bookmark", err)
+		}
+	})
+	input.SetText(ui.currentDir.GetName())
+	return input
+}
+
+// addBookmark stores the current directory under the name, replacing bookmark with the same name
+func (ui *UI) addBookmark(name string) error {
+	path, err := filepath.Abs(ui.currentDirPath)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := loadBookmarks(ui.bookmarksPath)
+	if err != nil {
+		return err
+	}
+
+	name = strings.ReplaceAll(name, "\t", " ")
+	res := []*bookmark{}
+	for _, b := range bookmarks {
+		if b.name != name {
+			res = append(res, b)
+		}
+	}
+	res = append(res, &bookmark{name: name, path: path})
+	return saveBookmarks(ui.bookmarksPath, res)
+}
+
+// showBookmarks shows bookmarked locations with their current sizes.
+// Enter opens the location if it is in the analyzed tree, d removes the bookmark.
+func (ui *UI) showBookmarks() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	bookmarks, err := loadBookmarks(ui.bookmarksPath)
+	if err != nil {
+		ui.showErr("Error reading bookmarks", err)
+		return nil
+	}
+
+	var numberColor string
+	if ui.UseColors {
+		numberColor = "[#e67100::b]"
+	} else {
+		numberColor = "[::b]"
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, fmt.Sprintf("%-20s %15s %s", "Name", "Size", "Path"))
+	if len(bookmarks) == 0 {
+		row = addViewHeader(table, row, "No bookmarks, press K in directory to add one")
+	}
+	for _, b := range bookmarks {
+		size := "-"
+		if item := ui.findPath(b.path); item != nil {
+			if ui.ShowApparentSize {
+				size = ui.formatSize(item.GetSize(), false, true)
+			} else {
+				size = ui.formatSize(item.GetUsage(), false, true)
+			}
+		}
+
+		cell := tview.NewTableCell(fmt.Sprintf(
+			"%-20s %s%15s[-::] %s",
+			tview.Escape(b.name), numberColor, size, tview.Escape(b.path),
+		))
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(b)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	if len(bookmarks) > 0 {
+		table.Select(1, 0)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if b, ok := table.GetCell(row, column).GetReference().(*bookmark); ok {
+			ui.closeView()
+			ui.openPath(b.path)
+		}
+	})
+
+	ui.showView("bookmarks", "Bookmarks", table)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.closeView()
+			return nil
+		}
+		if event.Rune() != 'd' {
+			return event
+		}
+
+		row, column := table.GetSelection()
+		if b, ok := table.GetCell(row, column).GetReference().(*bookmark); ok {
+			ui.removeBookmark(bookmarks, b)
+		}
+		return nil
+	})
+	return table
+}
+
+func (ui *UI) removeBookmark(bookmarks []*bookmark, removed *bookmark) {
+	res := []*bookmark{}
+	for _, b := range bookmarks {
+		if b != removed {
+			res = append(res, b)
+		}
+	}
+	if err := saveBookmarks(ui.bookmarksPath, res); err != nil {
+		ui.showErr("Error saving bookmarks", err)
+		return
+	}
+	ui.showBookmarks()
+}
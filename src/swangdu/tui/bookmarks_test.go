@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+)
+
+func TestAddAndOpenBookmark(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+	ui.bookmarksPath = filepath.Join(t.TempDir(), "gdu", "bookmarks")
+
+	ui.openPath("nested/subnested")
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'K', 0))
+	assert.True(t, ui.pages.HasPage("bookmark"))
+
+	input := ui.showAddBookmarkInput()
+	assert.Equal(t, "subnested", input.GetText())
+	input.SetText("sub")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	assert.False(t, ui.pages.HasPage("bookmark"))
+
+	bookmarks, err := loadBookmarks(ui.bookmarksPath)
+	assert.Nil(t, err)
+	assert.Len(t, bookmarks, 1)
+	assert.Equal(t, "sub", bookmarks[0].name)
+	abs, _ := filepath.Abs("test_dir/nested/subnested")
+	assert.Equal(t, abs, bookmarks[0].path)
+
+	ui.openPath(".")
+	table := ui.showBookmarks()
+	assert.Equal(t, "bookmarks", ui.activeView)
+	assert.Contains(t, table.GetCell(1, 0).Text, "sub")
+	assert.Contains(t, table.GetCell(1, 0).Text, abs)
+
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+}
+
+func TestRemoveBookmark(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+	ui.bookmarksPath = filepath.Join(t.TempDir(), "bookmarks")
+	err := saveBookmarks(ui.bookmarksPath, []*bookmark{
+		{name: "gone", path: "/outside/of/tree"},
+		{name: "top", path: "/somewhere"},
+	})
+	assert.Nil(t, err)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, '\'', 0))
+	assert.Equal(t, "bookmarks", ui.activeView)
+
+	table := ui.showBookmarks()
+	assert.Contains(t, table.GetCell(1, 0).Text, "gone")
+	assert.Contains(t, table.GetCell(1, 0).Text, "-[-::]")
+
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'd', 0), nil)
+
+	bookmarks, err := loadBookmarks(ui.bookmarksPath)
+	assert.Nil(t, err)
+	assert.Len(t, bookmarks, 1)
+	assert.Equal(t, "top", bookmarks[0].name)
+}
+
+func TestShowNoBookmarks(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.bookmarksPath = filepath.Join(t.TempDir(), "missing")
+
+	table := ui.showBookmarks()
+	assert.Contains(t, table.GetCell(1, 0).Text, "No bookmarks")
+
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'q', 0), nil)
+	assert.Equal(t, "", ui.activeView)
+}
+
+func TestShowBookmarksWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showBookmarks())
+	assert.Nil(t, ui.showAddBookmarkInput())
+}
+
+func TestLoadBookmarksSkipsInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks")
+	err := os.WriteFile(path, []byte("invalid\nname\t/path\n"), 0o600)
+	assert.Nil(t, err)
+
+	bookmarks, err := loadBookmarks(path)
+	assert.Nil(t, err)
+	assert.Len(t, bookmarks, 1)
+	assert.Equal(t, "/path", bookmarks[0].path)
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// findDuplicates searches for files with identical content in the current directory
+// and shows them in the duplicates view when done
+func (ui *UI) findDuplicates() {
+	if ui.currentDir == nil {
+		return
+	}
+
+	progress := tview.NewTextView().SetText("Comparing files...")
+	progress.SetBorder(true).SetBorderPadding(2, 2, 2, 2)
+	progress.SetTitle(" Searching for duplicates... ")
+
+	flex := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 10, 1, false).
+			AddItem(progress, 8, 1, false).
+			AddItem(nil, 10, 1, false), 0, 50, false).
+		AddItem(nil, 0, 1, false)
+
+	ui.pages.AddPage("progress", flex, true, true)
+
+	dir := ui.currentDir
+	go func() {
+		sets := analyze.FindDuplicates(dir)
+
+		ui.app.QueueUpdateDraw(func() {
+			ui.pages.RemovePage("progress")
+			ui.showDuplicates(sets)
+		})
+
+		if ui.done != nil {
+			ui.done <- struct{}{}
+		}
+	}()
+}
+
+// showDuplicates shows sets of duplicate files.
+// The file under cursor is kept when other files of its set are deleted (d) or replaced by hard links (H).
+func (ui *UI) showDuplicates(sets analyze.DuplicateSets) *tview.Table {
+	setOf := make(map[fs.Item]*analyze.DuplicateSet)
+
+	var total int64
+	for _, set := range sets {
+		total += set.Reclaimable()
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, "Total reclaimable: "+ui.formatSize(total, false, false))
+	for _, set := range sets {
+		row = addViewHeader(table, row, "")
+		row = addViewHeader(table, row, fmt.Sprintf(
+			"%s reclaimable, %d copies of %s:",
+			ui.formatSize(set.Reclaimable(), false, false),
+			len(set.Files),
+			ui.formatSize(set.Size, false, false),
+		))
+		row = ui.addFilesRows(table, row, set.Files)
+		for _, file := range set.Files {
+			setOf[file] = set
+		}
+	}
+	if len(sets) > 0 {
+		table.Select(3, 0)
+	}
+
+	ui.showView(
+		"duplicates",
+		"Duplicate files: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.closeView()
+			return nil
+		}
+		if event.Rune() != 'd' && event.Rune() != 'H' {
+			return event
+		}
+
+		row, column := table.GetSelection()
+		keep, ok := table.GetCell(row, column).GetReference().(fs.Item)
+		if !ok {
+			return nil
+		}
+		ui.confirmDuplicatesAction(sets, setOf[keep], keep, event.Rune() == 'H')
+		return nil
+	})
+	return table
+}
+
+func (ui *UI) confirmDuplicatesAction(
+	sets analyze.DuplicateSets, set *analyze.DuplicateSet, keep fs.Item, link bool,
+) {
+	var action string
+	if link {
+		action = "replace with hard links"
+	} else {
+		action = "delete"
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf(
+			"Are you sure you want to %s %d copies of \"%s\"?",
+			action,
+			len(set.Files)-1,
+			tview.Escape(keep.GetName()),
+		)).
+		AddButtons([]string{"yes", "no"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("confirm")
+			if buttonIndex == 0 {
+				ui.resolveDuplicates(sets, set, keep, link)
+			}
+		})
+
+	if !ui.UseColors {
+		modal.SetBackgroundColor(tcell.ColorGray)
+	} else {
+		modal.SetBackgroundColor(tcell.ColorBlack)
+	}
+	modal.SetBorderColor(tcell.ColorDefault)
+
+	ui.pages.AddPage("confirm", modal, true, true)
+}
+
+// resolveDuplicates deletes or hard links all files of the set except the kept one
+func (ui *UI) resolveDuplicates(
+	sets analyze.DuplicateSets, set *analyze.DuplicateSet, keep fs.Item, link bool,
+) {
+	var err error
+	remaining := fs.Files{keep}
+	for _, file := range set.Files {
+		if file == keep {
+			continue
+		}
+		if isExcluded(file) {
+			remaining = append(remaining, file)
+			continue
+		}
+		if err == nil {
+			if link {
+				err = ui.linker(keep, file)
+			} else {
+				err = ui.remover(file.GetParent(), file)
+			}
+		}
+		if err != nil {
+			remaining = append(remaining, file)
+		}
+	}
+
+	set.Files = remaining
+	if len(remaining) < 2 {
+		sets = removeDuplicateSet(sets, set)
+	}
+
+	ui.closeView()
+	ui.showDir()
+	ui.showDuplicates(sets)
+
+	if err != nil {
+		ui.showErr("Error resolving duplicates", err)
+	}
+}
+
+func removeDuplicateSet(sets analyze.DuplicateSets, set *analyze.DuplicateSet) analyze.DuplicateSets {
+	res := make(analyze.DuplicateSets, 0, len(sets))
+	for _, s := range sets {
+		if s != set {
+			res = append(res, s)
+		}
+	}
+	return res
+}
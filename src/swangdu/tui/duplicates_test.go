@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testapp"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicateFiles(t)
+
+	ui := getAnalyzedPathMockedApp(t, true, false, false)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'D', 0))
+	assert.True(t, ui.pages.HasPage("progress"))
+
+	<-ui.done // wait for comparing files
+
+	draws := ui.app.(*testapp.MockedApp).UpdateDraws
+	draws[len(draws)-1]()
+
+	assert.False(t, ui.pages.HasPage("progress"))
+	assert.Equal(t, "duplicates", ui.activeView)
+}
+
+func TestFindDuplicatesWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, false, true)
+	ui.currentDir = nil
+
+	ui.findDuplicates()
+
+	assert.False(t, ui.pages.HasPage("progress"))
+}
+
+func TestShowDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicateFiles(t)
+
+	ui := getAnalyzedPathMockedApp(t, true, false, false)
+	table := ui.showDuplicates(analyze.FindDuplicates(ui.currentDir))
+
+	assert.Equal(t, 6, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "Total reclaimable:")
+	assert.Contains(t, table.GetCell(2, 0).Text, "3 copies of 5")
+	assert.Contains(t, table.GetCell(3, 0).Text, "test_dir/dup")
+	assert.Contains(t, table.GetCell(4, 0).Text, "test_dir/nested/dup")
+	assert.Contains(t, table.GetCell(5, 0).Text, "test_dir/nested/subnested/file")
+
+	row, _ := table.GetSelection()
+	assert.Equal(t, 3, row)
+}
+
+func TestDeleteDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicateFiles(t)
+
+	ui := getAnalyzedPathMockedApp(t, false, false, false)
+	sets := analyze.FindDuplicates(ui.currentDir)
+	table := ui.showDuplicates(sets)
+
+	table.Select(5, 0)
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+	assert.True(t, ui.pages.HasPage("confirm"))
+
+	keep := table.GetCell(5, 0).GetReference().(fs.Item)
+	ui.resolveDuplicates(sets, sets[0], keep, false)
+
+	assert.NoFileExists(t, "test_dir/dup")
+	assert.NoFileExists(t, "test_dir/nested/dup")
+	assert.FileExists(t, "test_dir/nested/subnested/file")
+	assert.Equal(t, "duplicates", ui.activeView)
+	assert.Equal(t, 1, ui.table.GetRowCount()) // only nested dir left
+}
+
+func TestHardLinkDuplicates(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicateFiles(t)
+
+	ui := getAnalyzedPathMockedApp(t, false, false, false)
+	sets := analyze.FindDuplicates(ui.currentDir)
+
+	ui.resolveDuplicates(sets, sets[0], sets[0].Files[2], true)
+
+	keepInfo, err := os.Stat("test_dir/nested/subnested/file")
+	assert.Nil(t, err)
+	dupInfo, err := os.Stat("test_dir/dup")
+	assert.Nil(t, err)
+	assert.True(t, os.SameFile(keepInfo, dupInfo))
+	assert.Equal(t, 1, len(sets[0].Files))
+}
+
+func TestHardLinkDuplicatesFailing(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	createDuplicateFiles(t)
+
+	ui := getAnalyzedPathMockedApp(t, false, false, false)
+	ui.linker = func(_, _ fs.Item) error {
+		return errors.New("linking failed")
+	}
+	sets := analyze.FindDuplicates(ui.currentDir)
+	table := ui.showDuplicates(sets)
+
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'H', 0))
+	assert.True(t, ui.pages.HasPage("confirm"))
+
+	ui.resolveDuplicates(sets, sets[0], sets[0].Files[0], true)
+
+	assert.True(t, ui.pages.HasPage("error"))
+	assert.Equal(t, 3, len(sets[0].Files))
+	assert.FileExists(t, "test_dir/dup")
+}
+
+func TestCloseDuplicates(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, false, true)
+
+	table := ui.showDuplicates(nil)
+	assert.Equal(t, "duplicates", ui.activeView)
+	assert.Contains(t, table.GetCell(0, 0).Text, "Total reclaimable: 0")
+
+	// no file selected
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'H', 0))
+	assert.False(t, ui.pages.HasPage("confirm"))
+
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'q', 0))
+	assert.Equal(t, "", ui.activeView)
+}
+
+func createDuplicateFiles(t *testing.T) {
+	assert.Nil(t, os.WriteFile("test_dir/dup", []byte("hello"), 0600))
+	assert.Nil(t, os.WriteFile("test_dir/nested/dup", []byte("hello"), 0600))
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+)
+
+// showScanErrors shows errors which occurred during the analysis of the current directory.
+// Enter jumps to the directory where the error occurred.
+func (ui *UI) showScanErrors() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	errs := analyze.GetScanErrors(ui.currentDir)
+
+	table := ui.createViewTable()
+	row := 0
+	for _, summary := range analyze.SummarizeScanErrors(errs) {
+		row = addViewHeader(table, row, summary.String())
+	}
+	if len(errs) == 0 {
+		row = addViewHeader(table, row, "No errors")
+	}
+	row = addViewHeader(table, row, "")
+	first := row
+	for _, err := range errs {
+		cell := tview.NewTableCell(fmt.Sprintf(
+			"%-8s %s: %s",
+			err.Op,
+			tview.Escape(strings.TrimPrefix(err.GetPath(), build.RootPathPrefix)),
+			tview.Escape(err.Msg),
+		))
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(err)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	if len(errs) > 0 {
+		table.Select(first, 0)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		err, ok := table.GetCell(row, column).GetReference().(*analyze.DirError)
+		if !ok {
+			return
+		}
+		ui.closeView()
+		if err.Op == analyze.OpReadDir {
+			ui.jumpTo(err.Dir)
+		} else {
+			ui.pushHistory()
+			ui.currentDir = err.Dir.(*analyze.Dir)
+			ui.hideFilterInput()
+			ui.showDir()
+		}
+	})
+
+	ui.showView(
+		"errors",
+		"Scan errors: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+)
+
+func TestShowScanErrors(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	dir := ui.currentDir.(*analyze.Dir)
+	aaa := dir.Files[0].(*analyze.Dir)
+	aaa.Errors = []*analyze.ScanError{{Op: analyze.OpReadDir, Msg: "permission denied"}}
+	dir.Errors = []*analyze.ScanError{{Name: "gone", Op: analyze.OpStat, Msg: "no such file or directory"}}
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'E', 0))
+	assert.Equal(t, "errors", ui.activeView)
+
+	table := ui.showScanErrors()
+	assert.Equal(t, 5, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "1 directory unreadable: permission denied")
+	assert.Contains(t, table.GetCell(1, 0).Text, "1 entry inaccessible: no such file or directory")
+	assert.Equal(t, "readdir  test_dir/aaa: permission denied", table.GetCell(3, 0).Text)
+	assert.Equal(t, "stat     test_dir/gone: no such file or directory", table.GetCell(4, 0).Text)
+
+	row, _ := table.GetSelection()
+	assert.Equal(t, 3, row)
+
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, dir, ui.currentDir)
+	row, _ = ui.table.GetSelection()
+	assert.Equal(t, aaa, ui.table.GetCell(row, 0).GetReference())
+}
+
+func TestShowScanErrorsJumpsIntoDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	aaa := ui.currentDir.(*analyze.Dir).Files[0].(*analyze.Dir)
+	aaa.Errors = []*analyze.ScanError{{Name: "gone", Op: analyze.OpStat, Msg: "no such file or directory"}}
+
+	table := ui.showScanErrors()
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.Equal(t, aaa, ui.currentDir)
+}
+
+func TestShowScanErrorsWithoutErrors(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	table := ui.showScanErrors()
+	assert.Contains(t, table.GetCell(0, 0).Text, "No errors")
+}
+
+func TestShowScanErrorsWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showScanErrors())
+}
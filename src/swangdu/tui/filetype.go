@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+const largestFilesOfTypeCount = 100
+
+// fileType is reference of row in the file types view
+type fileType struct {
+	name       string
+	isCategory bool
+}
+
+// showFileTypes shows disk usage of the current directory aggregated by file categories and extensions
+func (ui *UI) showFileTypes() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	categories, extensions := analyze.GetUsageByType(ui.currentDir)
+	if ui.ShowApparentSize {
+		sort.Sort(analyze.TypesByApparentSize(categories))
+		sort.Sort(analyze.TypesByApparentSize(extensions))
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, "Categories:")
+	row = ui.addFileTypesRows(table, row, categories, true)
+	row = addViewHeader(table, row, "")
+	row = addViewHeader(table, row, "Extensions:")
+	ui.addFileTypesRows(table, row, extensions, false)
+	table.Select(1, 0)
+
+	table.SetSelectedFunc(func(row, column int) {
+		if ref, ok := table.GetCell(row, column).GetReference().(*fileType); ok {
+			ui.showLargestFilesOfType(ref)
+		}
+	})
+
+	ui.showView(
+		"types",
+		"Usage by file type: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
+
+func (ui *UI) addFileTypesRows(
+	table *tview.Table, row int, types analyze.TypesUsage, isCategory bool,
+) int {
+	for _, typeUsage := range types {
+		cell := tview.NewTableCell(ui.formatSummaryRow(
+			typeUsage.Name,
+			typeUsage.Usage,
+			typeUsage.Size,
+			typeUsage.FileCount,
+			ui.currentDir.GetUsage(),
+			ui.currentDir.GetSize(),
+		))
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(&fileType{name: typeUsage.Name, isCategory: isCategory})
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	return row
+}
+
+// showLargestFilesOfType shows the largest files of given category or extension in the current directory.
+// Esc or q returns back to the file types view.
+func (ui *UI) showLargestFilesOfType(ref *fileType) *tview.Table {
+	filter := func(item fs.Item) bool {
+		if ref.isCategory {
+			return analyze.GetCategory(item.GetName()) == ref.name
+		}
+		return analyze.GetExtension(item.GetName()) == ref.name
+	}
+	files := analyze.GetLargestFiles(
+		ui.currentDir, largestFilesOfTypeCount, ui.ShowApparentSize, filter,
+	)
+
+	table := ui.createViewTable()
+	ui.addFilesRows(table, 0, files)
+
+	ui.showView(
+		"typefiles",
+		fmt.Sprintf("Largest files of type %s: %s",
+			ref.name, strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		),
+		table,
+	)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.showFileTypes()
+			return nil
+		}
+		return event
+	})
+	return table
+}
+
+// addFilesRows adds files with their full paths into the view table and returns index of next row
+func (ui *UI) addFilesRows(table *tview.Table, row int, files fs.Files) int {
+	var numberColor string
+	if ui.UseColors {
+		numberColor = "[#e67100::b]"
+	} else {
+		numberColor = "[::b]"
+	}
+
+	for _, file := range files {
+		text := numberColor
+		if ui.ShowApparentSize {
+			text += fmt.Sprintf("%15s", ui.formatSize(file.GetSize(), false, true))
+		} else {
+			text += fmt.Sprintf("%15s", ui.formatSize(file.GetUsage(), false, true))
+		}
+		text += " " + tview.Escape(
+			strings.TrimPrefix(file.GetPath(), build.RootPathPrefix),
+		)
+
+		cell := tview.NewTableCell(text)
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(file)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	return row
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowFileTypes(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, false, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 't', 0))
+
+	assert.Equal(t, "types", ui.activeView)
+	assert.True(t, ui.pages.HasPage("types"))
+}
+
+func TestShowFileTypesContent(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	table := ui.showFileTypes()
+
+	assert.Contains(t, table.GetCell(0, 0).Text, "Categories:")
+	assert.Contains(t, table.GetCell(1, 0).Text, "Other")
+	assert.Contains(t, table.GetCell(3, 0).Text, "Extensions:")
+	assert.Contains(t, table.GetCell(4, 0).Text, "(none)")
+}
+
+func TestShowFileTypesWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showFileTypes())
+}
+
+func TestShowLargestFilesOfType(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, false, true)
+
+	ui.showFileTypes()
+	table := ui.showLargestFilesOfType(&fileType{name: "Other", isCategory: true})
+
+	assert.Equal(t, "typefiles", ui.activeView)
+	assert.False(t, ui.pages.HasPage("types"))
+	assert.Equal(t, 1, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "test_dir/ddd")
+
+	// going back shows the file types again
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyEsc, 0, 0))
+
+	assert.Equal(t, "types", ui.activeView)
+	assert.False(t, ui.pages.HasPage("typefiles"))
+}
+
+func TestShowLargestFilesOfExtension(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	table := ui.showLargestFilesOfType(&fileType{name: "mp4"})
+
+	assert.Equal(t, 0, table.GetRowCount())
+}
@@ -135,3 +135,63 @@ func TestExitFiltering(t *testing.T) {
 	assert.Contains(t, ui.table.GetCell(0, 0).Text, "nested")
 	assert.Empty(t, ui.filterValue) // filtering reset
 }
+
+func TestFilteringWithPatterns(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.showFilterInput()
+	ui.filterValue = "c*"
+	ui.showDir()
+
+	assert.Equal(t, 1, ui.table.GetRowCount())
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "ccc")
+
+	ui.filterValue = "re:^(aaa|ccc)$"
+	ui.showDir()
+
+	assert.Equal(t, 2, ui.table.GetRowCount())
+
+	ui.filterValue = "CCC"
+	ui.showDir()
+
+	assert.Equal(t, "", ui.table.GetCell(0, 0).Text) // case sensitive with uppercase letters
+}
+
+func TestFilteringWithInvalidPattern(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.showFilterInput()
+	ui.filterValue = "re:("
+	ui.showDir()
+
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "aaa") // nothing is filtered
+	assert.Contains(t, ui.footerLabel.GetText(true), "Invalid filter: error parsing regexp")
+}
+
+func TestKeepFilter(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'P', 0))
+	assert.True(t, ui.keepFilter)
+	assert.Contains(t, ui.footerLabel.GetText(true), "Filter: kept")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, '/', 0))
+	ui.filterValue = "file*"
+	ui.filtering = false
+	ui.showDir()
+
+	ui.table.Select(0, 0)
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRight, 'l', 0)) // open nested dir
+
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	assert.Equal(t, "file*", ui.filterValue)
+	assert.Equal(t, 3, ui.table.GetRowCount()) // parent, subnested and file2
+	assert.Contains(t, ui.table.GetCell(1, 0).Text, "subnested")
+	assert.Contains(t, ui.table.GetCell(2, 0).Text, "file2")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'P', 0))
+	assert.False(t, ui.keepFilter)
+}
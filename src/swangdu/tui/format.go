@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ungtb10d/gdu/v5/internal/common"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
 	"github.com/rivo/tview"
 )
@@ -42,6 +43,15 @@ func (ui *UI) formatFileRow(item fs.Item, maxUsage int64, maxSize int64) string
 		row += fmt.Sprintf("%11s ", ui.formatCount(item.GetItemCount()))
 	}
 
+	if ui.showUsageRatio {
+		if ui.UseColors {
+			row += "[#e67100::b]"
+		} else {
+			row += "[::b]"
+		}
+		row += fmt.Sprintf("%8s [-::]", formatUsageRatio(analyze.GetUsageRatio(item)))
+	}
+
 	if ui.showMtime {
 		if ui.UseColors {
 			row += "[#e67100::b]"
@@ -50,7 +60,15 @@ func (ui *UI) formatFileRow(item fs.Item, maxUsage int64, maxSize int64) string
 		}
 		row += fmt.Sprintf(
 			"%s [-::]",
-			item.GetMtime().Format("2006-01-02 15:04:05"),
+			ui.getItemTime(item).Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	if ui.showOwner {
+		row += fmt.Sprintf(
+			"%-8s %-8s ",
+			tview.Escape(ui.resolver.UserName(item.GetUID())),
+			tview.Escape(ui.resolver.GroupName(item.GetGID())),
 		)
 	}
 
@@ -65,6 +83,44 @@ func (ui *UI) formatFileRow(item fs.Item, maxUsage int64, maxSize int64) string
 	return row
 }
 
+// formatSummaryRow formats row with usage aggregated over multiple items, e.g. by owner
+func (ui *UI) formatSummaryRow(
+	name string, usage int64, size int64, itemCount int, maxUsage int64, maxSize int64,
+) string {
+	var part int
+
+	if ui.ShowApparentSize {
+		part = int(float64(size) / float64(maxSize) * 10.0)
+	} else {
+		part = int(float64(usage) / float64(maxUsage) * 10.0)
+	}
+
+	var numberColor string
+	if ui.UseColors {
+		numberColor = "[#e67100::b]"
+	} else {
+		numberColor = "[::b]"
+	}
+
+	row := numberColor
+	if ui.ShowApparentSize {
+		row += fmt.Sprintf("%15s", ui.formatSize(size, false, true))
+	} else {
+		row += fmt.Sprintf("%15s", ui.formatSize(usage, false, true))
+	}
+	row += getUsageGraph(part)
+	row += numberColor + fmt.Sprintf("%11s ", ui.formatCount(itemCount))
+	row += tview.Escape(name)
+	return row
+}
+
+func formatUsageRatio(ratio float64) string {
+	if ratio >= 1000 {
+		return ">999x"
+	}
+	return fmt.Sprintf("%.2fx", ratio)
+}
+
 func (ui *UI) formatSize(size int64, reverseColor bool, transparentBg bool) string {
 	var color string
 	if reverseColor {
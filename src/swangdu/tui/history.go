@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"path/filepath"
+
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+)
+
+// pushHistory remembers the current directory before navigating elsewhere, forward history is dropped
+func (ui *UI) pushHistory() {
+	if ui.currentDir == nil {
+		return
+	}
+	ui.backHistory = append(ui.backHistory, ui.currentDirRelPath())
+	ui.forwardHistory = nil
+}
+
+// goBack returns to the previously visited directory
+func (ui *UI) goBack() {
+	ui.moveInHistory(&ui.backHistory, &ui.forwardHistory)
+}
+
+// goForward returns to the directory visited before going back
+func (ui *UI) goForward() {
+	ui.moveInHistory(&ui.forwardHistory, &ui.backHistory)
+}
+
+// moveInHistory opens the last directory of from history and remembers the current directory in to history.
+// Directories which are no longer in the analyzed tree are skipped.
+func (ui *UI) moveInHistory(from, to *[]string) {
+	if ui.currentDir == nil {
+		return
+	}
+
+	for len(*from) > 0 {
+		path := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+
+		dir, ok := analyze.FindItem(ui.topDir, path).(*analyze.Dir)
+		if !ok {
+			continue
+		}
+
+		*to = append(*to, ui.currentDirRelPath())
+		ui.currentDir = dir
+		ui.hideFilterInput()
+		ui.showDir()
+		return
+	}
+}
+
+// currentDirRelPath returns path of the current directory relative to the top directory
+func (ui *UI) currentDirRelPath() string {
+	path, err := filepath.Rel(ui.topDirPath, ui.currentDirPath)
+	if err != nil {
+		return "."
+	}
+	return path
+}
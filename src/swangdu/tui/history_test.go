@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+)
+
+func TestHistoryBackAndForward(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.openPath("nested")
+	ui.openPath("subnested")
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, '[', 0))
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, '[', 0))
+	assert.Equal(t, "test_dir", ui.currentDir.GetName())
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, '[', 0))
+	assert.Equal(t, "test_dir", ui.currentDir.GetName())
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, ']', 0))
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, ']', 0))
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, ']', 0))
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+}
+
+func TestHistoryDropsForwardOnNavigation(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.openPath("nested/subnested")
+	ui.goBack()
+	assert.Equal(t, []string{"nested/subnested"}, ui.forwardHistory)
+
+	ui.openPath("nested")
+	assert.Empty(t, ui.forwardHistory)
+	assert.Equal(t, []string{"."}, ui.backHistory)
+}
+
+func TestHistorySkipsRemovedDirs(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.openPath("nested/subnested")
+	ui.openPath("..")
+	ui.backHistory = append(ui.backHistory, "missing")
+
+	ui.goBack()
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+}
+
+func TestHistoryWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+	ui.backHistory = []string{"."}
+
+	ui.goBack()
+	ui.pushHistory()
+	assert.Equal(t, []string{"."}, ui.backHistory)
+}
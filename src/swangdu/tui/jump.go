@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// SetStartAt sets path inside the analyzed tree which is opened once the analysis is done
+func (ui *UI) SetStartAt(path string) {
+	ui.startAt = path
+}
+
+// showJumpInput asks for path to jump to, with tab completion of names in the analyzed tree.
+// Relative paths are resolved from the current directory.
+func (ui *UI) showJumpInput() *tview.InputField {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	input := ui.showInputPage("jump", "Jump to path", "Go to: ", func(path string) {
+		if path != "" {
+			ui.openPath(path)
+		}
+	})
+	input.SetAutocompleteFunc(ui.completePath)
+	return input
+}
+
+// findPath returns item on the path, which is either absolute or relative to the current directory
+func (ui *UI) findPath(path string) fs.Item {
+	if filepath.IsAbs(path) {
+		top, err := filepath.Abs(ui.topDirPath)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(top, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+		return analyze.FindItem(ui.topDir, rel)
+	}
+	return analyze.FindItem(ui.currentDir, path)
+}
+
+// openPath opens the directory on the path or the directory containing the file on the path
+func (ui *UI) openPath(path string) {
+	item := ui.findPath(path)
+	if item == nil {
+		ui.showErr("Error opening path", fmt.Errorf("%s not found in the analyzed tree", path))
+		return
+	}
+
+	if item.IsDir() {
+		ui.pushHistory()
+		ui.currentDir = item.(*analyze.Dir)
+		ui.hideFilterInput()
+		ui.showDir()
+		return
+	}
+	ui.jumpTo(item)
+}
+
+// openStartAt opens the path set by SetStartAt, just once
+func (ui *UI) openStartAt() {
+	if ui.startAt == "" {
+		return
+	}
+	path := ui.startAt
+	ui.startAt = ""
+	ui.openPath(path)
+}
+
+// completePath returns paths of the items starting with the last component of the given path
+func (ui *UI) completePath(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	dirPart, prefix := "", text
+	if index := strings.LastIndex(text, "/"); index > -1 {
+		dirPart, prefix = text[:index+1], text[index+1:]
+	}
+
+	var dir fs.Item
+	if dirPart == "" {
+		dir = ui.currentDir
+	} else {
+		dir = ui.findPath(dirPart)
+	}
+	if dir == nil || !dir.IsDir() {
+		return nil
+	}
+
+	entries := make([]string, 0)
+	for _, item := range dir.GetFiles() {
+		if !strings.HasPrefix(item.GetName(), prefix) {
+			continue
+		}
+		entry := dirPart + item.GetName()
+		if item.IsDir() {
+			entry += "/"
+		}
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	if len(entries) == 1 && entries[0] == text {
+		return nil
+	}
+	return entries
+}
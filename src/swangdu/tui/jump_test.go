@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testapp"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestShowJumpInput(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, ':', 0))
+	assert.True(t, ui.pages.HasPage("jump"))
+
+	input := ui.showJumpInput()
+	input.SetText("nested/subnested")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.False(t, ui.pages.HasPage("jump"))
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'g', 0))
+	assert.True(t, ui.pages.HasPage("jump"))
+}
+
+func TestShowJumpInputWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showJumpInput())
+}
+
+func TestOpenPathToFile(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	abs, err := filepath.Abs("test_dir/nested/file2")
+	assert.Nil(t, err)
+	ui.topDirPath, err = filepath.Abs(ui.topDirPath)
+	assert.Nil(t, err)
+	ui.openPath(abs)
+
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	row, _ := ui.table.GetSelection()
+	assert.Equal(t, "file2", ui.table.GetCell(row, 0).GetReference().(fs.Item).GetName())
+}
+
+func TestOpenMissingPath(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.openPath("nested/missing")
+	assert.True(t, ui.pages.HasPage("error"))
+	assert.Equal(t, "test_dir", ui.currentDir.GetName())
+
+	assert.Nil(t, ui.findPath("/outside/of/tree"))
+}
+
+func TestCompletePath(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	assert.Equal(t, []string{"nested/"}, ui.completePath("n"))
+	assert.Equal(t, []string{"nested/file2", "nested/subnested/"}, ui.completePath("nested/"))
+	assert.Equal(t, []string{"nested/subnested/file"}, ui.completePath("nested/subnested/f"))
+	assert.Nil(t, ui.completePath("nested/subnested/file"))
+	assert.Nil(t, ui.completePath("missing/"))
+	assert.Nil(t, ui.completePath(""))
+}
+
+func TestStartAt(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	simScreen := testapp.CreateSimScreen(50, 50)
+	defer simScreen.Fini()
+
+	app := testapp.CreateMockedApp(true)
+	ui := CreateUI(app, simScreen, &bytes.Buffer{}, false, true, false, false, false)
+	ui.SetStartAt("nested/subnested")
+	ui.done = make(chan struct{})
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	<-ui.done // wait for analyzer
+
+	for _, f := range ui.app.(*testapp.MockedApp).UpdateDraws {
+		f()
+	}
+
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+	assert.Equal(t, "", ui.startAt)
+}
@@ -3,12 +3,18 @@ package tui
 import (
 	"fmt"
 
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
 	"github.com/gdamore/tcell/v2"
 )
 
 func (ui *UI) keyPressed(key *tcell.EventKey) *tcell.EventKey {
-	if ui.pages.HasPage("file") {
+	if ui.pages.HasPage("file") ||
+		ui.pages.HasPage("olderthan") ||
+		ui.pages.HasPage("searchinput") ||
+		ui.pages.HasPage("jump") ||
+		ui.pages.HasPage("bookmark") ||
+		ui.activeView != "" {
 		return key // send event to primitive
 	}
 	if ui.filtering {
@@ -135,6 +141,48 @@ func (ui *UI) keyPressed(key *tcell.EventKey) *tcell.EventKey {
 			ui.showDir()
 			ui.table.Select(row, column)
 		}
+	case 'w':
+		ui.switchTimeField()
+		if ui.currentDir != nil {
+			row, column := ui.table.GetSelection()
+			ui.showDir()
+			ui.table.Select(row, column)
+		}
+	case 'R':
+		ui.showUsageRatio = !ui.showUsageRatio
+		if ui.currentDir != nil {
+			row, column := ui.table.GetSelection()
+			ui.showDir()
+			ui.table.Select(row, column)
+		}
+	case 'o':
+		ui.showOwner = !ui.showOwner
+		if ui.currentDir != nil {
+			row, column := ui.table.GetSelection()
+			ui.showDir()
+			ui.table.Select(row, column)
+		}
+	case 'U':
+		ui.showOwners()
+	case 't':
+		ui.showFileTypes()
+	case 'A':
+		ui.showAges()
+	case 'T':
+		ui.showOlderThanInput()
+		return nil
+	case 'S':
+		ui.showSparseFiles()
+	case 'D':
+		ui.findDuplicates()
+	case 'L':
+		ui.showBrokenSymlinks()
+	case 'E':
+		ui.showScanErrors()
+	case 'F':
+		ui.showTopFiles()
+	case 'V':
+		ui.showTreemap()
 	case 'r':
 		if ui.currentDir != nil {
 			ui.rescanDir()
@@ -146,10 +194,34 @@ func (ui *UI) keyPressed(key *tcell.EventKey) *tcell.EventKey {
 	case 'n':
 		ui.setSorting("name")
 	case 'M':
-		ui.setSorting("mtime")
+		ui.setSorting(ui.timeField)
+	case 'O':
+		ui.setSorting("owner")
 	case '/':
 		ui.showFilterInput()
 		return nil
+	case 'f':
+		ui.showSearchInput()
+		return nil
+	case ':', 'g':
+		ui.showJumpInput()
+		return nil
+	case '[':
+		ui.goBack()
+	case ']':
+		ui.goForward()
+	case 'K':
+		ui.showAddBookmarkInput()
+		return nil
+	case '\'':
+		ui.showBookmarks()
+	case 'P':
+		ui.keepFilter = !ui.keepFilter
+		if ui.currentDir != nil {
+			row, column := ui.table.GetSelection()
+			ui.showDir()
+			ui.table.Select(row, column)
+		}
 	}
 	return key
 }
@@ -183,6 +255,12 @@ func (ui *UI) handleRight() {
 	}
 }
 
+// isExcluded returns true if the item was excluded from the analysis.
+// Such items must not be deleted because their content is not known.
+func isExcluded(item fs.Item) bool {
+	return analyze.GetExcludedReason(item.GetFlag()) != ""
+}
+
 func (ui *UI) handleDelete(shouldEmpty bool) {
 	if ui.currentDir == nil {
 		return
@@ -193,6 +271,9 @@ func (ui *UI) handleDelete(shouldEmpty bool) {
 	if selectedFile == ui.currentDir.GetParent() {
 		return
 	}
+	if isExcluded(selectedFile) {
+		return
+	}
 
 	if ui.askBeforeDelete {
 		ui.confirmDeletion(shouldEmpty)
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+)
+
+// showOwners shows disk usage of the current directory aggregated by users and groups
+func (ui *UI) showOwners() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	users, groups := analyze.GetUsageByOwner(ui.currentDir)
+	if ui.ShowApparentSize {
+		sort.Sort(analyze.OwnersByApparentSize(users))
+		sort.Sort(analyze.OwnersByApparentSize(groups))
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, "Users:")
+	row = ui.addOwnersRows(table, row, users, ui.resolver.UserName)
+	row = addViewHeader(table, row, "")
+	row = addViewHeader(table, row, "Groups:")
+	ui.addOwnersRows(table, row, groups, ui.resolver.GroupName)
+	table.Select(1, 0)
+
+	ui.showView(
+		"owners",
+		"Usage by owner: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
+
+func (ui *UI) addOwnersRows(
+	table *tview.Table, row int, owners analyze.OwnersUsage, getName func(uint32) string,
+) int {
+	for _, owner := range owners {
+		name := analyze.UnknownOwner
+		if !owner.Unknown {
+			name = getName(owner.ID)
+		}
+		cell := tview.NewTableCell(ui.formatSummaryRow(
+			name,
+			owner.Usage,
+			owner.Size,
+			owner.ItemCount,
+			ui.currentDir.GetUsage(),
+			ui.currentDir.GetSize(),
+		))
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	return row
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/owner"
+)
+
+func TestShowOwners(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, false, true)
+	ui.resolver = &owner.Resolver{PasswdPath: "/xxx", GroupPath: "/xxx"}
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'U', 0))
+
+	assert.Equal(t, "owners", ui.activeView)
+	assert.True(t, ui.pages.HasPage("owners"))
+}
+
+func TestShowOwnersContent(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.resolver = &owner.Resolver{PasswdPath: "/xxx", GroupPath: "/xxx"}
+
+	table := ui.showOwners()
+
+	assert.Contains(t, table.GetCell(0, 0).Text, "Users:")
+	assert.Contains(t, table.GetCell(1, 0).Text, "] 0")
+	assert.Contains(t, table.GetCell(2, 0).Text, "] 1001")
+	assert.Contains(t, table.GetCell(3, 0).Text, "] 1002")
+	assert.Contains(t, table.GetCell(4, 0).Text, "] 1003")
+	assert.Contains(t, table.GetCell(6, 0).Text, "Groups:")
+	assert.Contains(t, table.GetCell(7, 0).Text, "] 100")
+	assert.Contains(t, table.GetCell(8, 0).Text, "] 0")
+}
+
+func TestCloseOwners(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	table := ui.showOwners()
+	assert.True(t, ui.pages.HasPage("owners"))
+
+	// keys are passed to the view
+	assert.NotNil(t, ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'q', 0)))
+
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'q', 0))
+
+	assert.Equal(t, "", ui.activeView)
+	assert.False(t, ui.pages.HasPage("owners"))
+	assert.Contains(t, ui.currentDirLabel.GetText(false), "test_dir")
+}
+
+func TestShowOwnersWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showOwners())
+	assert.False(t, ui.pages.HasPage("owners"))
+}
+
+func TestShowOwnerColumn(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+	ui.resolver = &owner.Resolver{PasswdPath: "/xxx", GroupPath: "/xxx"}
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'o', 0))
+
+	assert.True(t, ui.showOwner)
+	assert.Contains(t, ui.table.GetCell(1, 0).Text, "1002     100")
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ungtb10d/gdu/v5/internal/common"
+	"github.com/ungtb10d/gdu/v5/report"
 )
 
 func (ui *UI) updateProgress() {
@@ -40,3 +41,33 @@ func (ui *UI) updateProgress() {
 		time.Sleep(100 * time.Millisecond)
 	}
 }
+
+// updateReadProgress shows number of items and bytes read from the analysis file until done is closed
+func (ui *UI) updateReadProgress(reader *report.AnalysisReader, done chan struct{}) {
+	color := "[white:black:b]"
+	if ui.UseColors {
+		color = "[red:black:b]"
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		bytesRead, itemCount, totalSize := reader.GetProgress()
+		text := "Reading analysis from file...\nTotal items: " +
+			color + common.FormatNumber(int64(itemCount)) +
+			"[white:black:-] read: " +
+			color + ui.formatSize(bytesRead, false, false)
+		if totalSize > 0 {
+			text += "[white:black:-] of " + color + ui.formatSize(totalSize, false, false)
+		}
+		text += "[white:black:-]"
+
+		ui.app.QueueUpdateDraw(func() {
+			ui.progress.SetText(text)
+		})
+	}
+}
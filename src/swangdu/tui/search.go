@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// searchResults are items found by the search together with the items marked for deletion
+type searchResults struct {
+	pattern string
+	files   fs.Files
+	marked  map[fs.Item]struct{}
+}
+
+// showSearchInput asks for pattern to search for in the whole analyzed tree
+func (ui *UI) showSearchInput() *tview.InputField {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	return ui.showInputPage("searchinput", "Search in the whole tree", "Search (text, glob or re:regexp): ", func(pattern string) {
+		if pattern != "" {
+			ui.search(pattern)
+		}
+	})
+}
+
+// search finds items matching the pattern in the whole analyzed tree
+func (ui *UI) search(pattern string) *tview.Table {
+	match, err := analyze.CompileNamePattern(pattern)
+	if err != nil {
+		ui.showErr("Invalid search pattern", err)
+		return nil
+	}
+
+	return ui.showSearchResults(&searchResults{
+		pattern: pattern,
+		files:   analyze.SearchItems(ui.topDir, match, ui.ShowApparentSize),
+		marked:  make(map[fs.Item]struct{}),
+	})
+}
+
+// showSearchResults shows found items with their total size.
+// Enter jumps to the selected item, space marks it for deletion and d deletes the marked items
+// (or the selected one if none is marked).
+func (ui *UI) showSearchResults(results *searchResults) *tview.Table {
+	var total, markedTotal int64
+	for _, file := range results.files {
+		total += ui.itemSize(file)
+		if _, ok := results.marked[file]; ok {
+			markedTotal += ui.itemSize(file)
+		}
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, fmt.Sprintf(
+		"%d items, total %s",
+		len(results.files),
+		ui.formatSize(total, false, false),
+	))
+	if len(results.marked) > 0 {
+		row = addViewHeader(table, row, fmt.Sprintf(
+			"%d marked for deletion, total %s",
+			len(results.marked),
+			ui.formatSize(markedTotal, false, false),
+		))
+	}
+	row = addViewHeader(table, row, "")
+	first := row
+	row = ui.addFilesRows(table, row, results.files)
+	for i := first; i < row; i++ {
+		cell := table.GetCell(i, 0)
+		if _, ok := results.marked[cell.GetReference().(fs.Item)]; ok {
+			cell.SetText("[::b]*[::-]" + cell.Text)
+		} else {
+			cell.SetText(" " + cell.Text)
+		}
+	}
+	if len(results.files) > 0 {
+		table.Select(first, 0)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if file, ok := table.GetCell(row, column).GetReference().(fs.Item); ok {
+			ui.closeView()
+			ui.jumpTo(file)
+		}
+	})
+
+	ui.showView(
+		"search",
+		fmt.Sprintf("Search for %s: %s",
+			results.pattern, strings.TrimPrefix(ui.topDirPath, build.RootPathPrefix),
+		),
+		table,
+	)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.closeView()
+			return nil
+		}
+		if event.Rune() != ' ' && event.Rune() != 'd' {
+			return event
+		}
+
+		row, column := table.GetSelection()
+		file, ok := table.GetCell(row, column).GetReference().(fs.Item)
+		if !ok {
+			return nil
+		}
+
+		if event.Rune() == 'd' {
+			ui.confirmSearchDeletion(results, file)
+			return nil
+		}
+
+		if _, ok := results.marked[file]; ok {
+			delete(results.marked, file)
+		} else {
+			results.marked[file] = struct{}{}
+		}
+		newTable := ui.showSearchResults(results)
+		if row = selectReference(newTable, file); row+1 < newTable.GetRowCount() {
+			newTable.Select(row+1, 0)
+		}
+		return nil
+	})
+	return table
+}
+
+func (ui *UI) confirmSearchDeletion(results *searchResults, selected fs.Item) {
+	items := fs.Files{}
+	for _, file := range results.files {
+		if _, ok := results.marked[file]; ok && !isExcluded(file) {
+			items = append(items, file)
+		}
+	}
+
+	var text string
+	if len(items) == 0 {
+		if isExcluded(selected) {
+			return
+		}
+		items = fs.Files{selected}
+		text = fmt.Sprintf("Are you sure you want to delete \"%s\"?", tview.Escape(selected.GetName()))
+	} else {
+		text = fmt.Sprintf("Are you sure you want to delete %d marked items?", len(items))
+	}
+
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"yes", "no"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("confirm")
+			if buttonIndex == 0 {
+				ui.deleteSearchResults(results, items)
+			}
+		})
+
+	if !ui.UseColors {
+		modal.SetBackgroundColor(tcell.ColorGray)
+	} else {
+		modal.SetBackgroundColor(tcell.ColorBlack)
+	}
+	modal.SetBorderColor(tcell.ColorDefault)
+
+	ui.pages.AddPage("confirm", modal, true, true)
+}
+
+// deleteSearchResults deletes the items and removes them from the search results
+func (ui *UI) deleteSearchResults(results *searchResults, items fs.Files) {
+	var err error
+	for _, item := range items {
+		if isExcluded(item) {
+			continue
+		}
+		if err = ui.remover(item.GetParent(), item); err != nil {
+			break
+		}
+		results.files = results.files.Remove(item)
+		delete(results.marked, item)
+	}
+
+	ui.closeView()
+	ui.showDir()
+	ui.showSearchResults(results)
+
+	if err != nil {
+		ui.showErr("Error deleting items", err)
+	}
+}
+
+func (ui *UI) itemSize(item fs.Item) int64 {
+	if ui.ShowApparentSize {
+		return item.GetSize()
+	}
+	return item.GetUsage()
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testapp"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestShowSearchInput(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'f', 0))
+	assert.True(t, ui.pages.HasPage("searchinput"))
+
+	input := ui.showSearchInput()
+	input.SetText("ccc")
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.False(t, ui.pages.HasPage("searchinput"))
+	assert.Equal(t, "search", ui.activeView)
+}
+
+func TestShowSearchInputWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showSearchInput())
+}
+
+func TestSearch(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	table := ui.search("file*")
+	assert.Equal(t, 4, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "2 items, total 7")
+	assert.Contains(t, table.GetCell(2, 0).Text, "test_dir/nested/subnested/file")
+	assert.Contains(t, table.GetCell(3, 0).Text, "test_dir/nested/file2")
+
+	table.Select(3, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	row, _ := ui.table.GetSelection()
+	assert.Equal(t, "file2", ui.table.GetCell(row, 0).GetReference().(fs.Item).GetName())
+}
+
+func TestSearchWithWrongPattern(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	assert.Nil(t, ui.search("re:("))
+	assert.True(t, ui.pages.HasPage("error"))
+}
+
+func TestMarkAndDeleteSearchResults(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	match, err := analyze.CompileNamePattern("file")
+	assert.Nil(t, err)
+	results := &searchResults{
+		pattern: "file",
+		files:   analyze.SearchItems(ui.topDir, match, true),
+		marked:  make(map[fs.Item]struct{}),
+	}
+
+	table := ui.showSearchResults(results)
+	assert.Contains(t, table.GetCell(2, 0).Text, "test_dir/nested/subnested/file")
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, ' ', 0))
+
+	assert.Len(t, results.marked, 1)
+	table = ui.showSearchResults(results)
+	assert.Contains(t, table.GetCell(1, 0).Text, "1 marked for deletion, total 5")
+	assert.Contains(t, table.GetCell(3, 0).Text, "[::b]*[::-]")
+	assert.NotContains(t, table.GetCell(4, 0).Text, "[::b]*[::-]")
+
+	table.Select(4, 0)
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+	assert.True(t, ui.pages.HasPage("confirm"))
+
+	ui.deleteSearchResults(results, fs.Files{results.files[0]})
+
+	assert.NoFileExists(t, "test_dir/nested/subnested/file")
+	assert.FileExists(t, "test_dir/nested/file2")
+	assert.Len(t, results.files, 1)
+	assert.Len(t, results.marked, 0)
+	assert.Equal(t, "search", ui.activeView)
+}
+
+func TestDeleteExcludedSearchResults(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+	simScreen := testapp.CreateSimScreen(50, 50)
+	defer simScreen.Fini()
+
+	app := testapp.CreateMockedApp(true)
+	ui := CreateUI(app, simScreen, &bytes.Buffer{}, false, true, false, false, false)
+	ui.done = make(chan struct{})
+	ui.SetIgnoreDirPaths([]string{"test_dir/nested"})
+	err := ui.AnalyzePath("test_dir", nil)
+	assert.Nil(t, err)
+
+	<-ui.done // wait for analyzer
+
+	for _, f := range ui.app.(*testapp.MockedApp).UpdateDraws {
+		f()
+	}
+
+	match, err := analyze.CompileNamePattern("nested")
+	assert.Nil(t, err)
+	results := &searchResults{
+		pattern: "nested",
+		files:   analyze.SearchItems(ui.topDir, match, true),
+		marked:  make(map[fs.Item]struct{}),
+	}
+
+	table := ui.showSearchResults(results)
+	assert.Contains(t, table.GetCell(2, 0).Text, "test_dir/nested")
+
+	// neither selected nor marked excluded item is deleted
+	table.Select(2, 0)
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+	assert.False(t, ui.pages.HasPage("confirm"))
+
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, ' ', 0))
+	assert.Len(t, results.marked, 1)
+	table = ui.showSearchResults(results)
+	table.Select(2, 0)
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+	assert.False(t, ui.pages.HasPage("confirm"))
+
+	ui.deleteSearchResults(results, results.files)
+	assert.DirExists(t, "test_dir/nested")
+}
@@ -3,8 +3,10 @@ package tui
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -52,11 +54,21 @@ func (ui *UI) showDir() {
 		maxSize = ui.currentDir.GetSize()
 	}
 
+	var (
+		match     func(string) bool
+		filterErr error
+	)
+	if ui.filterValue != "" {
+		match, filterErr = analyze.CompileNamePattern(ui.filterValue)
+	}
+
+	now := time.Now()
 	for i, item := range ui.currentDir.GetFiles() {
-		if ui.filterValue != "" && !strings.Contains(
-			strings.ToLower(item.GetName()),
-			strings.ToLower(ui.filterValue),
-		) {
+		// directories are kept in the listing when the filter is kept, so that they can be entered
+		if match != nil && !match(item.GetName()) && !(ui.keepFilter && item.IsDir()) {
+			continue
+		}
+		if ui.olderThan > 0 && !analyze.IsOlderThan(item, ui.olderThan, now) {
 			continue
 		}
 
@@ -81,16 +93,30 @@ func (ui *UI) showDir() {
 		footerTextColor = "[black:white:-]"
 	}
 
-	ui.footerLabel.SetText(
-		" Total disk usage: " +
-			footerNumberColor +
-			ui.formatSize(totalUsage, true, false) +
-			" Apparent size: " +
-			footerNumberColor +
-			ui.formatSize(totalSize, true, false) +
-			" Items: " + footerNumberColor + strconv.Itoa(itemCount) +
-			footerTextColor +
-			" Sorting by: " + ui.sortBy + " " + ui.sortOrder)
+	footerText := " Total disk usage: " +
+		footerNumberColor +
+		ui.formatSize(totalUsage, true, false) +
+		" Apparent size: " +
+		footerNumberColor +
+		ui.formatSize(totalSize, true, false) +
+		" Items: " + footerNumberColor + strconv.Itoa(itemCount) +
+		footerTextColor +
+		" Sorting by: " + ui.sortBy + " " + ui.sortOrder
+	if ui.timeField != "mtime" {
+		footerText += " Time: " + ui.timeField
+	}
+	if ui.olderThan > 0 {
+		footerText += " Older than: " + footerNumberColor +
+			strconv.Itoa(int(ui.olderThan/analyze.Day)) + " days" + footerTextColor
+	}
+	if ui.keepFilter {
+		footerText += " Filter: kept"
+	}
+	if filterErr != nil {
+		footerText += " Invalid filter: " + footerNumberColor +
+			tview.Escape(filterErr.Error()) + footerTextColor
+	}
+	ui.footerLabel.SetText(footerText)
 
 	ui.table.Select(0, 0)
 	ui.table.ScrollToBeginning()
@@ -176,6 +202,44 @@ func (ui *UI) showErr(msg string, err error) {
 	ui.pages.AddPage("error", modal, true, true)
 }
 
+// showInputPage shows modal input field in the middle of the screen.
+// done is called with the entered text when enter is pressed, the page is closed by enter or escape.
+func (ui *UI) showInputPage(name, title, label string, done func(text string)) *tview.InputField {
+	input := tview.NewInputField().SetLabel(label)
+	input.SetBorder(true).SetBorderPadding(1, 1, 1, 1)
+	input.SetBorderColor(tcell.ColorDefault)
+	input.SetTitle(" " + title + " ")
+
+	if !ui.UseColors {
+		input.SetFieldBackgroundColor(tcell.NewRGBColor(100, 100, 100))
+		input.SetFieldTextColor(tcell.NewRGBColor(255, 255, 255))
+	}
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		// tab completes the text
+		if key == tcell.KeyTab {
+			return
+		}
+		ui.pages.RemovePage(name)
+		ui.app.SetFocus(ui.table)
+		if key == tcell.KeyEnter {
+			done(input.GetText())
+		}
+	})
+
+	flex := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(input, 5, 1, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	ui.pages.AddPage(name, flex, true, true)
+	ui.app.SetFocus(input)
+	return input
+}
+
 func (ui *UI) showHelp() {
 	text := tview.NewTextView().SetDynamicColors(true)
 	text.SetBorder(true).SetBorderPadding(2, 2, 2, 2)
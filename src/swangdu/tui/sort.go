@@ -63,6 +63,27 @@ func (ui *UI) sortItems() {
 			sort.Sort(sort.Reverse(fs.ByMtime(ui.currentDir.GetFiles())))
 		}
 	}
+	if ui.sortBy == "atime" {
+		if ui.sortOrder == "desc" {
+			sort.Sort(fs.ByAtime(ui.currentDir.GetFiles()))
+		} else {
+			sort.Sort(sort.Reverse(fs.ByAtime(ui.currentDir.GetFiles())))
+		}
+	}
+	if ui.sortBy == "ctime" {
+		if ui.sortOrder == "desc" {
+			sort.Sort(fs.ByCtime(ui.currentDir.GetFiles()))
+		} else {
+			sort.Sort(sort.Reverse(fs.ByCtime(ui.currentDir.GetFiles())))
+		}
+	}
+	if ui.sortBy == "owner" {
+		if ui.sortOrder == "desc" {
+			sort.Sort(fs.ByOwner(ui.currentDir.GetFiles()))
+		} else {
+			sort.Sort(sort.Reverse(fs.ByOwner(ui.currentDir.GetFiles())))
+		}
+	}
 }
 
 func (ui *UI) sortDevices() {
@@ -155,6 +155,26 @@ func TestSortDevicesByUsedSize(t *testing.T) {
 	assert.Equal(t, "/dev/root", ui.devices[0].Name)
 }
 
+func TestSortByOwner(t *testing.T) {
+	ui := getAnalyzedPathWithSorting("owner", "desc", false)
+
+	assert.Equal(t, 4, ui.table.GetRowCount())
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "ddd")
+	assert.Contains(t, ui.table.GetCell(1, 0).Text, "bbb")
+	assert.Contains(t, ui.table.GetCell(2, 0).Text, "ccc")
+	assert.Contains(t, ui.table.GetCell(3, 0).Text, "aaa")
+}
+
+func TestSortByOwnerAsc(t *testing.T) {
+	ui := getAnalyzedPathWithSorting("owner", "asc", false)
+
+	assert.Equal(t, 4, ui.table.GetRowCount())
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "aaa")
+	assert.Contains(t, ui.table.GetCell(1, 0).Text, "ccc")
+	assert.Contains(t, ui.table.GetCell(2, 0).Text, "bbb")
+	assert.Contains(t, ui.table.GetCell(3, 0).Text, "ddd")
+}
+
 func getAnalyzedPathWithSorting(sortBy string, sortOrder string, apparentSize bool) *UI {
 	simScreen := testapp.CreateSimScreen(50, 50)
 	defer simScreen.Fini()
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+const usageRatioFilesCount = 100
+
+// showSparseFiles shows files in the current directory which use much less disk space than their size
+// (sparse or compressed) and files which use much more disk space than their size
+func (ui *UI) showSparseFiles() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, fmt.Sprintf(
+		"%15s %15s %8s", "Disk usage", "Apparent size", "Ratio",
+	))
+	row = addViewHeader(table, row, "")
+	row = addViewHeader(table, row, "Sparse or compressed files:")
+	row = ui.addUsageRatioRows(table, row, analyze.GetSparseFiles(ui.currentDir, usageRatioFilesCount))
+	row = addViewHeader(table, row, "")
+	row = addViewHeader(table, row, "Inflated files:")
+	ui.addUsageRatioRows(table, row, analyze.GetInflatedFiles(ui.currentDir, usageRatioFilesCount))
+	table.Select(3, 0)
+
+	ui.showView(
+		"sparse",
+		"Sparse and inflated files: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
+
+func (ui *UI) addUsageRatioRows(table *tview.Table, row int, files fs.Files) int {
+	var numberColor string
+	if ui.UseColors {
+		numberColor = "[#e67100::b]"
+	} else {
+		numberColor = "[::b]"
+	}
+
+	for _, file := range files {
+		text := numberColor + fmt.Sprintf(
+			"%15s %15s %8s",
+			ui.formatSize(file.GetUsage(), false, true),
+			ui.formatSize(file.GetSize(), false, true),
+			formatUsageRatio(analyze.GetUsageRatio(file)),
+		)
+		text += "[-::] " + tview.Escape(
+			strings.TrimPrefix(file.GetPath(), build.RootPathPrefix),
+		)
+
+		cell := tview.NewTableCell(text)
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(file)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	return row
+}
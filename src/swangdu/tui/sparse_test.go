@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestShowUsageRatio(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'R', 0))
+	assert.True(t, ui.showUsageRatio)
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "1.00x")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'R', 0))
+	assert.False(t, ui.showUsageRatio)
+	assert.NotContains(t, ui.table.GetCell(0, 0).Text, "1.00x")
+}
+
+func TestFormatUsageRatio(t *testing.T) {
+	assert.Equal(t, "0.50x", formatUsageRatio(0.5))
+	assert.Equal(t, "123.46x", formatUsageRatio(123.456))
+	assert.Equal(t, ">999x", formatUsageRatio(4096))
+}
+
+func TestShowSparseFiles(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	dir := ui.currentDir.(*analyze.Dir)
+	dir.AddFile(&analyze.File{
+		Name:   "vm.img",
+		Size:   1 << 30,
+		Usage:  1 << 20,
+		Parent: dir,
+	})
+	dir.AddFile(&analyze.File{
+		Name:   "tiny",
+		Size:   1,
+		Usage:  1 << 20,
+		Parent: dir,
+	})
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'S', 0))
+	assert.Equal(t, "sparse", ui.activeView)
+
+	table := ui.showSparseFiles()
+	assert.Equal(t, 7, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "Ratio")
+	assert.Contains(t, table.GetCell(2, 0).Text, "Sparse or compressed files:")
+	assert.Contains(t, table.GetCell(3, 0).Text, "0.00x[-::] test_dir/vm.img")
+	assert.Equal(t, "vm.img", table.GetCell(3, 0).GetReference().(fs.Item).GetName())
+	assert.Contains(t, table.GetCell(5, 0).Text, "Inflated files:")
+	assert.Contains(t, table.GetCell(6, 0).Text, ">999x[-::] test_dir/tiny")
+
+	row, _ := table.GetSelection()
+	assert.Equal(t, 3, row)
+}
+
+func TestShowSparseFilesWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showSparseFiles())
+}
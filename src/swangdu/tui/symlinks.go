@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+)
+
+// showBrokenSymlinks shows symlinks in the current directory whose target does not exist
+func (ui *UI) showBrokenSymlinks() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	links := analyze.GetBrokenSymlinks(ui.currentDir)
+
+	table := ui.createViewTable()
+	row := addViewHeader(table, 0, fmt.Sprintf("Broken symlinks: %d", len(links)))
+	row = addViewHeader(table, row, "")
+	for _, link := range links {
+		cell := tview.NewTableCell(
+			tview.Escape(strings.TrimPrefix(link.GetPath(), build.RootPathPrefix)) +
+				" -> " + tview.Escape(link.GetTarget()),
+		)
+		cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+		cell.SetReference(link)
+		table.SetCell(row, 0, cell)
+		row++
+	}
+	if len(links) > 0 {
+		table.Select(2, 0)
+	}
+
+	ui.showView(
+		"brokenlinks",
+		"Broken symlinks: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+	return table
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestShowBrokenSymlinks(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	err := os.Symlink("missing", "test_dir/dangling")
+	assert.Nil(t, err)
+
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	dir := ui.currentDir.(*analyze.Dir)
+	dir.AddFile(&analyze.File{
+		Name:       "dangling",
+		Flag:       '@',
+		Target:     "missing",
+		Parent:     dir,
+		BrokenLink: true,
+	})
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'L', 0))
+	assert.Equal(t, "brokenlinks", ui.activeView)
+
+	table := ui.showBrokenSymlinks()
+	assert.Equal(t, 3, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "Broken symlinks: 1")
+	assert.Equal(t, "test_dir/dangling -> missing", table.GetCell(2, 0).Text)
+	assert.Equal(t, "dangling", table.GetCell(2, 0).GetReference().(fs.Item).GetName())
+
+	row, _ := table.GetSelection()
+	assert.Equal(t, 2, row)
+}
+
+func TestShowBrokenSymlinksWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showBrokenSymlinks())
+}
+
+func TestShowInfoOfSymlink(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	dir := ui.currentDir.(*analyze.Dir)
+	dir.Files = fs.Files{&analyze.File{
+		Name:       "dangling",
+		Flag:       '@',
+		Target:     "missing",
+		Parent:     dir,
+		BrokenLink: true,
+	}}
+	ui.showDir()
+	ui.table.Select(0, 0)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'i', 0))
+	assert.True(t, ui.pages.HasPage("info"))
+
+	_, page := ui.pages.GetFrontPage()
+	text := page.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1).(*tview.TextView)
+	assert.Contains(t, text.GetText(true), "Target: missing (broken)")
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+var timeFields = []string{"mtime", "atime", "ctime"}
+
+// switchTimeField cycles the timestamp shown in the time column and used for sorting
+func (ui *UI) switchTimeField() {
+	next := timeFields[0]
+	for i, field := range timeFields {
+		if field == ui.timeField && i+1 < len(timeFields) {
+			next = timeFields[i+1]
+		}
+	}
+
+	if ui.sortBy == ui.timeField {
+		ui.sortBy = next
+	}
+	ui.timeField = next
+}
+
+func (ui *UI) getItemTime(item fs.Item) time.Time {
+	switch ui.timeField {
+	case "atime":
+		return item.GetAtime()
+	case "ctime":
+		return item.GetCtime()
+	}
+	return item.GetMtime()
+}
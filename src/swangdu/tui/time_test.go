@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwitchTimeField(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+
+	assert.Equal(t, "mtime", ui.timeField)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Equal(t, "atime", ui.timeField)
+	assert.Contains(t, ui.footerLabel.GetText(true), "Time: atime")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Equal(t, "ctime", ui.timeField)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Equal(t, "mtime", ui.timeField)
+	assert.NotContains(t, ui.footerLabel.GetText(true), "Time:")
+}
+
+func TestShowAtime(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'm', 0))
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "2021-08-27 22:23:27")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "2021-08-27 22:23:24")
+}
+
+func TestSortByAtime(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'M', 0))
+
+	assert.Equal(t, "atime", ui.sortBy)
+	assert.Equal(t, "asc", ui.sortOrder)
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "aaa")
+	assert.Contains(t, ui.table.GetCell(3, 0).Text, "ddd")
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'M', 0))
+
+	assert.Equal(t, "desc", ui.sortOrder)
+	assert.Contains(t, ui.table.GetCell(0, 0).Text, "ddd")
+	assert.Contains(t, ui.table.GetCell(3, 0).Text, "aaa")
+}
+
+func TestSwitchTimeFieldKeepsTimeSorting(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'M', 0))
+	assert.Equal(t, "mtime", ui.sortBy)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Equal(t, "atime", ui.sortBy)
+	assert.Equal(t, "asc", ui.sortOrder)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'w', 0))
+	assert.Equal(t, "ctime", ui.sortBy)
+}
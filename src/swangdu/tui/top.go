@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+const topFilesCount = 100
+
+var errHiddenByFilter = errors.New("the file is hidden by the age filter")
+
+// showTopFiles shows the largest files in the whole subtree of the current directory.
+// Enter jumps to the directory of the selected file, d deletes the file and v shows its content.
+func (ui *UI) showTopFiles() *tview.Table {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	files := analyze.GetLargestFiles(ui.currentDir, topFilesCount, ui.ShowApparentSize, nil)
+
+	table := ui.createViewTable()
+	ui.addFilesRows(table, 0, files)
+
+	table.SetSelectedFunc(func(row, column int) {
+		if file, ok := table.GetCell(row, column).GetReference().(fs.Item); ok {
+			ui.closeView()
+			ui.jumpTo(file)
+		}
+	})
+
+	ui.showView(
+		"topfiles",
+		"Largest files: "+strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix),
+		table,
+	)
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.closeView()
+			return nil
+		}
+		if event.Rune() != 'd' && event.Rune() != 'v' {
+			return event
+		}
+
+		row, column := table.GetSelection()
+		file, ok := table.GetCell(row, column).GetReference().(fs.Item)
+		if !ok {
+			return nil
+		}
+		ui.closeView()
+		// the action is done on the selected row so it must be the file
+		if !ui.jumpTo(file) {
+			ui.showErr("Can't select "+tview.Escape(file.GetName()), errHiddenByFilter)
+			return nil
+		}
+		if event.Rune() == 'd' {
+			ui.handleDelete(false)
+		} else {
+			ui.showFile()
+		}
+		return nil
+	})
+	return table
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testdir"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestShowTopFiles(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'F', 0))
+	assert.Equal(t, "topfiles", ui.activeView)
+
+	table := ui.showTopFiles()
+	assert.Equal(t, 2, table.GetRowCount())
+	assert.Contains(t, table.GetCell(0, 0).Text, "test_dir/nested/subnested/file")
+	assert.Contains(t, table.GetCell(1, 0).Text, "test_dir/nested/file2")
+}
+
+func TestShowTopFilesJumpToFile(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	table := ui.showTopFiles()
+	table.Select(1, 0)
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, "nested", ui.currentDir.GetName())
+	row, _ := ui.table.GetSelection()
+	assert.Equal(t, "file2", ui.table.GetCell(row, 0).GetReference().(fs.Item).GetName())
+}
+
+func TestShowTopFilesDeleteFile(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	table := ui.showTopFiles()
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+	assert.True(t, ui.pages.HasPage("confirm"))
+}
+
+func TestShowTopFilesViewFile(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+
+	table := ui.showTopFiles()
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'v', 0))
+
+	assert.Equal(t, "subnested", ui.currentDir.GetName())
+	assert.True(t, ui.pages.HasPage("file"))
+}
+
+func TestShowTopFilesDeleteFileHiddenByAgeFilter(t *testing.T) {
+	fin := testdir.CreateTestDir()
+	defer fin()
+
+	ui := getAnalyzedPathMockedApp(t, false, true, false)
+	ui.olderThan = 365 * analyze.Day
+
+	table := ui.showTopFiles()
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', 0))
+
+	assert.True(t, ui.pages.HasPage("error"))
+	assert.False(t, ui.pages.HasPage("confirm"))
+
+	table = ui.showTopFiles()
+	table.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'v', 0))
+
+	assert.False(t, ui.pages.HasPage("file"))
+	assert.FileExists(t, "test_dir/nested/subnested/file")
+}
+
+func TestShowTopFilesWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showTopFiles())
+}
@@ -0,0 +1,392 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+// terminal cells are roughly twice as tall as wide,
+// the layout is computed with scaled height so rectangles look square on the screen
+const cellAspectRatio = 2
+
+var treemapColors = []tcell.Color{
+	tcell.NewRGBColor(36, 121, 208),
+	tcell.NewRGBColor(230, 113, 0),
+	tcell.NewRGBColor(39, 174, 96),
+	tcell.NewRGBColor(142, 68, 173),
+	tcell.NewRGBColor(192, 57, 43),
+	tcell.NewRGBColor(22, 160, 133),
+	tcell.NewRGBColor(211, 84, 0),
+	tcell.NewRGBColor(41, 128, 185),
+}
+
+var treemapBwColors = []tcell.Color{
+	tcell.ColorGray,
+	tcell.ColorSilver,
+}
+
+// rect is rectangle in layout coordinates
+type rect struct {
+	x, y, w, h float64
+}
+
+// treemapTile is item of the treemap placed on the screen, coordinates are relative to the treemap
+type treemapTile struct {
+	item       fs.Item
+	x, y, w, h int
+}
+
+// treemap shows items of a directory as rectangles with area proportional to their size
+type treemap struct {
+	*tview.Box
+	ui       *UI
+	dir      fs.Item
+	tiles    []treemapTile
+	selected int
+	width    int
+	height   int
+}
+
+// squarify splits the rectangle into rectangles with areas proportional to sizes
+// keeping them as close to squares as possible.
+// Sizes must be sorted in descending order.
+func squarify(sizes []float64, r rect) []rect {
+	var total float64
+	for _, size := range sizes {
+		total += size
+	}
+	if total <= 0 || r.w <= 0 || r.h <= 0 {
+		return nil
+	}
+
+	areas := make([]float64, len(sizes))
+	for i, size := range sizes {
+		areas[i] = size / total * r.w * r.h
+	}
+
+	res := make([]rect, 0, len(sizes))
+	for i := 0; i < len(areas); {
+		short := math.Min(r.w, r.h)
+		j := i + 1
+		for j < len(areas) && worstRatio(areas[i:j+1], short) <= worstRatio(areas[i:j], short) {
+			j++
+		}
+
+		var sum float64
+		for _, area := range areas[i:j] {
+			sum += area
+		}
+
+		if r.w >= r.h {
+			width := sum / r.h
+			y := r.y
+			for _, area := range areas[i:j] {
+				res = append(res, rect{x: r.x, y: y, w: width, h: area / width})
+				y += area / width
+			}
+			r.x += width
+			r.w -= width
+		} else {
+			height := sum / r.w
+			x := r.x
+			for _, area := range areas[i:j] {
+				res = append(res, rect{x: x, y: r.y, w: area / height, h: height})
+				x += area / height
+			}
+			r.y += height
+			r.h -= height
+		}
+		i = j
+	}
+	return res
+}
+
+// worstRatio returns the worst aspect ratio of rectangles in the row laid along the side
+func worstRatio(row []float64, side float64) float64 {
+	var sum float64
+	maxArea, minArea := row[0], row[0]
+	for _, area := range row {
+		sum += area
+		maxArea = math.Max(maxArea, area)
+		minArea = math.Min(minArea, area)
+	}
+	return math.Max(
+		side*side*maxArea/(sum*sum),
+		sum*sum/(side*side*minArea),
+	)
+}
+
+// showTreemap shows treemap of the current directory
+func (ui *UI) showTreemap() *treemap {
+	if ui.currentDir == nil {
+		return nil
+	}
+
+	tm := &treemap{
+		Box: tview.NewBox(),
+		ui:  ui,
+	}
+	tm.SetBackgroundColor(tcell.ColorDefault)
+	tm.setDir(ui.currentDir)
+
+	ui.showViewPrimitive("treemap", ui.treemapTitle(ui.currentDir), tm)
+	tm.updateFooter()
+	return tm
+}
+
+func (ui *UI) treemapTitle(dir fs.Item) string {
+	return "Treemap of " + strings.TrimPrefix(dir.GetPath(), build.RootPathPrefix)
+}
+
+// setDir shows items of the directory
+func (tm *treemap) setDir(dir fs.Item) {
+	tm.dir = dir
+	tm.tiles = nil
+	tm.selected = 0
+	_, _, width, height := tm.GetInnerRect()
+	tm.layout(width, height)
+}
+
+// layout places items of the directory into area of given size.
+// Items too small to get at least one cell are omitted.
+func (tm *treemap) layout(width, height int) {
+	var selectedItem fs.Item
+	if tm.selected < len(tm.tiles) {
+		selectedItem = tm.tiles[tm.selected].item
+	}
+
+	tm.width, tm.height = width, height
+	tm.tiles = nil
+	tm.selected = 0
+
+	files := make(fs.Files, 0, len(tm.dir.GetFiles()))
+	for _, file := range tm.dir.GetFiles() {
+		if tm.ui.itemSize(file) > 0 {
+			files = append(files, file)
+		}
+	}
+	if tm.ui.ShowApparentSize {
+		sort.Sort(fs.ByApparentSize(files))
+	} else {
+		sort.Sort(files)
+	}
+
+	sizes := make([]float64, len(files))
+	for i, file := range files {
+		sizes[i] = float64(tm.ui.itemSize(file))
+	}
+
+	rects := squarify(sizes, rect{w: float64(width), h: float64(height * cellAspectRatio)})
+	for i, r := range rects {
+		x0, x1 := int(math.Round(r.x)), int(math.Round(r.x+r.w))
+		y0 := int(math.Round(r.y / cellAspectRatio))
+		y1 := int(math.Round((r.y + r.h) / cellAspectRatio))
+		if x1 <= x0 || y1 <= y0 {
+			continue
+		}
+		if files[i] == selectedItem {
+			tm.selected = len(tm.tiles)
+		}
+		tm.tiles = append(tm.tiles, treemapTile{item: files[i], x: x0, y: y0, w: x1 - x0, h: y1 - y0})
+	}
+}
+
+// Draw draws the treemap
+func (tm *treemap) Draw(screen tcell.Screen) {
+	tm.Box.DrawForSubclass(screen, tm)
+	x, y, width, height := tm.GetInnerRect()
+	if width != tm.width || height != tm.height {
+		tm.layout(width, height)
+		tm.updateFooter()
+	}
+
+	if len(tm.tiles) == 0 {
+		tview.Print(screen, "No items with non-zero size", x, y, width, tview.AlignCenter, tcell.ColorDefault)
+		return
+	}
+
+	colors := treemapBwColors
+	if tm.ui.UseColors {
+		colors = treemapColors
+	}
+
+	for i, tile := range tm.tiles {
+		style := tcell.StyleDefault.Background(colors[i%len(colors)]).Foreground(tcell.ColorBlack)
+		if i == tm.selected {
+			style = tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack)
+		}
+		for row := tile.y; row < tile.y+tile.h; row++ {
+			for col := tile.x; col < tile.x+tile.w; col++ {
+				screen.SetContent(x+col, y+row, ' ', nil, style)
+			}
+		}
+
+		name := tview.Escape(tile.item.GetName())
+		if tile.item.IsDir() {
+			name = "[::b]/" + name
+		}
+		tview.Print(screen, name, x+tile.x, y+tile.y, tile.w, tview.AlignLeft, tcell.ColorBlack)
+		if tile.h > 1 {
+			size := tm.ui.formatSize(tm.ui.itemSize(tile.item), false, true)
+			tview.Print(screen, size, x+tile.x, y+tile.y+1, tile.w, tview.AlignLeft, tcell.ColorBlack)
+		}
+	}
+}
+
+// InputHandler handles moving between tiles, enter descends into directory and backspace goes to the parent
+func (tm *treemap) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return tm.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch {
+		case event.Rune() == 'q' || event.Key() == tcell.KeyESC:
+			tm.close()
+			return
+		case event.Key() == tcell.KeyEnter:
+			tm.enter()
+		case event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2:
+			tm.up()
+		case event.Key() == tcell.KeyLeft || event.Rune() == 'h':
+			tm.move(-1, 0)
+		case event.Key() == tcell.KeyRight || event.Rune() == 'l':
+			tm.move(1, 0)
+		case event.Key() == tcell.KeyUp || event.Rune() == 'k':
+			tm.move(0, -1)
+		case event.Key() == tcell.KeyDown || event.Rune() == 'j':
+			tm.move(0, 1)
+		}
+		tm.updateFooter()
+	})
+}
+
+// move selects the nearest tile in given direction
+func (tm *treemap) move(dx, dy int) {
+	if len(tm.tiles) == 0 {
+		return
+	}
+
+	cur := tm.tiles[tm.selected]
+	best, bestDistance := -1, math.MaxFloat64
+	for i, tile := range tm.tiles {
+		var primary, secondary float64
+		switch {
+		case dx > 0 && tile.x >= cur.x+cur.w:
+			primary = float64(tile.x - (cur.x + cur.w))
+			secondary = overlapDistance(cur.y, cur.h, tile.y, tile.h)
+		case dx < 0 && tile.x+tile.w <= cur.x:
+			primary = float64(cur.x - (tile.x + tile.w))
+			secondary = overlapDistance(cur.y, cur.h, tile.y, tile.h)
+		case dy > 0 && tile.y >= cur.y+cur.h:
+			primary = float64(tile.y - (cur.y + cur.h))
+			secondary = overlapDistance(cur.x, cur.w, tile.x, tile.w)
+		case dy < 0 && tile.y+tile.h <= cur.y:
+			primary = float64(cur.y - (tile.y + tile.h))
+			secondary = overlapDistance(cur.x, cur.w, tile.x, tile.w)
+		default:
+			continue
+		}
+
+		distance := primary*primary + 4*secondary*secondary
+		if distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	if best >= 0 {
+		tm.selected = best
+	}
+}
+
+// overlapDistance returns gap between two intervals, zero if they overlap
+func overlapDistance(start1, length1, start2, length2 int) float64 {
+	switch {
+	case start2 >= start1+length1:
+		return float64(start2 - (start1 + length1) + 1)
+	case start1 >= start2+length2:
+		return float64(start1 - (start2 + length2) + 1)
+	default:
+		return 0
+	}
+}
+
+func (tm *treemap) selectedItem() fs.Item {
+	if tm.selected >= len(tm.tiles) {
+		return nil
+	}
+	return tm.tiles[tm.selected].item
+}
+
+// enter descends into the selected directory
+func (tm *treemap) enter() {
+	item := tm.selectedItem()
+	if item == nil || !item.IsDir() {
+		return
+	}
+	tm.setDir(item)
+	tm.ui.currentDirLabel.SetText("[::b] --- " + tview.Escape(tm.ui.treemapTitle(item)) + " ---")
+}
+
+// up shows the parent directory with the current directory selected
+func (tm *treemap) up() {
+	if tm.dir == tm.ui.topDir || tm.dir.GetParent() == nil {
+		return
+	}
+	prev := tm.dir
+	tm.setDir(prev.GetParent())
+	for i, tile := range tm.tiles {
+		if tile.item == prev {
+			tm.selected = i
+		}
+	}
+	tm.ui.currentDirLabel.SetText("[::b] --- " + tview.Escape(tm.ui.treemapTitle(tm.dir)) + " ---")
+}
+
+// close closes the treemap and selects the selected item in the directory listing
+func (tm *treemap) close() {
+	item := tm.selectedItem()
+	tm.ui.closeView()
+	if item == nil {
+		if tm.dir != tm.ui.currentDir {
+			tm.ui.jumpTo(tm.dir)
+		}
+		return
+	}
+	if item.GetParent() != tm.ui.currentDir {
+		tm.ui.jumpTo(item)
+		return
+	}
+	selectReference(tm.ui.table, item)
+}
+
+// updateFooter shows information about the selected item
+func (tm *treemap) updateFooter() {
+	item := tm.selectedItem()
+	if item == nil {
+		return
+	}
+
+	var footerNumberColor, footerTextColor string
+	if tm.ui.UseColors {
+		footerNumberColor = "[#ffffff:#2479d0:b]"
+		footerTextColor = "[black:#2479d0:-]"
+	} else {
+		footerNumberColor = "[black:white:b]"
+		footerTextColor = "[black:white:-]"
+	}
+
+	var percent float64
+	if total := tm.ui.itemSize(tm.dir); total > 0 {
+		percent = float64(tm.ui.itemSize(item)) / float64(total) * 100
+	}
+
+	tm.ui.footerLabel.SetText(fmt.Sprintf(
+		" %s%s%s Size: %s%s%s (%.1f%%)",
+		footerNumberColor, tview.Escape(item.GetName()), footerTextColor,
+		footerNumberColor, tm.ui.formatSize(tm.ui.itemSize(item), true, false), footerTextColor,
+		percent,
+	))
+}
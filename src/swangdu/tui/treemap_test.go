@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ungtb10d/gdu/v5/internal/testapp"
+	"github.com/ungtb10d/gdu/v5/pkg/analyze"
+	"github.com/ungtb10d/gdu/v5/pkg/fs"
+)
+
+func TestSquarify(t *testing.T) {
+	sizes := []float64{6, 6, 4, 3, 2, 2, 1}
+	rects := squarify(sizes, rect{w: 6, h: 4})
+
+	assert.Len(t, rects, len(sizes))
+	for i, r := range rects {
+		assert.InDelta(t, sizes[i], r.w*r.h, 0.0001)
+		assert.GreaterOrEqual(t, r.x, 0.0)
+		assert.GreaterOrEqual(t, r.y, 0.0)
+		assert.LessOrEqual(t, r.x+r.w, 6.0001)
+		assert.LessOrEqual(t, r.y+r.h, 4.0001)
+	}
+
+	// first two items fill the left column as squares
+	assert.Equal(t, rect{x: 0, y: 0, w: 3, h: 2}, rects[0])
+	assert.Equal(t, rect{x: 0, y: 2, w: 3, h: 2}, rects[1])
+}
+
+func TestSquarifyEmpty(t *testing.T) {
+	assert.Nil(t, squarify([]float64{}, rect{w: 6, h: 4}))
+	assert.Nil(t, squarify([]float64{0, 0}, rect{w: 6, h: 4}))
+	assert.Nil(t, squarify([]float64{1}, rect{w: 0, h: 4}))
+}
+
+func TestShowTreemap(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, true, true, true)
+	setTreemapTestDir(ui)
+
+	ui.keyPressed(tcell.NewEventKey(tcell.KeyRune, 'V', 0))
+	assert.Equal(t, "treemap", ui.activeView)
+
+	tm := ui.showTreemap()
+	assert.Len(t, tm.tiles, 3)
+	assert.Equal(t, "big", tm.selectedItem().GetName())
+	assert.Contains(t, ui.footerLabel.GetText(true), "(60.0%)")
+
+	// big is laid over the whole width, mid and small share the rest below it
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, 0), nil)
+	assert.Equal(t, "mid", tm.selectedItem().GetName())
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'l', 0), nil)
+	assert.Equal(t, "small", tm.selectedItem().GetName())
+	assert.Contains(t, ui.footerLabel.GetText(true), "small")
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyRight, 0, 0), nil)
+	assert.Equal(t, "small", tm.selectedItem().GetName())
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'h', 0), nil)
+	assert.Equal(t, "mid", tm.selectedItem().GetName())
+
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	assert.Equal(t, "top", tm.dir.GetName())
+
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, 0), nil)
+	assert.Equal(t, "big", tm.selectedItem().GetName())
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, 0), nil)
+	assert.Equal(t, "big", tm.dir.GetName())
+	assert.Len(t, tm.tiles, 2)
+	assert.Contains(t, ui.currentDirLabel.GetText(true), "Treemap of top/big")
+
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyBackspace2, 0, 0), nil)
+	assert.Equal(t, "top", tm.dir.GetName())
+	assert.Equal(t, "big", tm.selectedItem().GetName())
+
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyBackspace2, 0, 0), nil)
+	assert.Equal(t, "top", tm.dir.GetName())
+
+	tm.move(0, 1)
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'q', 0), nil)
+	assert.Equal(t, "", ui.activeView)
+	row, _ := ui.table.GetSelection()
+	assert.Equal(t, "mid", ui.table.GetCell(row, 0).GetReference().(fs.Item).GetName())
+}
+
+func TestTreemapCloseJumpsToSelectedItem(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	setTreemapTestDir(ui)
+
+	tm := ui.showTreemap()
+	tm.enter()
+	tm.move(0, 1)
+	assert.Equal(t, "b", tm.selectedItem().GetName())
+
+	tm.InputHandler()(tcell.NewEventKey(tcell.KeyESC, 0, 0), nil)
+	assert.Equal(t, "", ui.activeView)
+	assert.Equal(t, "big", ui.currentDir.GetName())
+	row, _ := ui.table.GetSelection()
+	assert.Equal(t, "b", ui.table.GetCell(row, 0).GetReference().(fs.Item).GetName())
+}
+
+func TestDrawTreemap(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	setTreemapTestDir(ui)
+	simScreen := testapp.CreateSimScreen(50, 50)
+	defer simScreen.Fini()
+
+	tm := ui.showTreemap()
+	tm.SetRect(0, 0, 40, 10)
+	tm.Draw(simScreen)
+	simScreen.Show()
+
+	assert.Equal(t, 40, tm.width)
+	assert.Contains(t, screenText(simScreen), "/big")
+	assert.Contains(t, screenText(simScreen), "mid")
+	assert.Contains(t, screenText(simScreen), "small")
+
+	tm.setDir(&analyze.Dir{File: &analyze.File{Name: "empty", Parent: ui.topDir}})
+	tm.Draw(simScreen)
+	simScreen.Show()
+	assert.Contains(t, screenText(simScreen), "No items with non-zero size")
+}
+
+func TestShowTreemapWithoutCurrentDir(t *testing.T) {
+	ui := getAnalyzedPathMockedApp(t, false, true, true)
+	ui.currentDir = nil
+
+	assert.Nil(t, ui.showTreemap())
+}
+
+// setTreemapTestDir opens directory with items of sizes 600 (directory with 400 and 200), 300 and 100
+func setTreemapTestDir(ui *UI) {
+	top := &analyze.Dir{
+		File:     &analyze.File{Name: "top", Size: 1000, Usage: 1000},
+		BasePath: ".",
+	}
+	big := &analyze.Dir{
+		File: &analyze.File{Name: "big", Size: 600, Usage: 600, Parent: top},
+	}
+	big.Files = fs.Files{
+		&analyze.File{Name: "a", Size: 400, Usage: 400, Parent: big},
+		&analyze.File{Name: "b", Size: 200, Usage: 200, Parent: big},
+	}
+	top.Files = fs.Files{
+		&analyze.File{Name: "small", Size: 100, Usage: 100, Parent: top},
+		big,
+		&analyze.File{Name: "mid", Size: 300, Usage: 300, Parent: top},
+	}
+
+	ui.topDir = top
+	ui.topDirPath = top.GetPath()
+	ui.currentDir = top
+	ui.showDir()
+}
+
+func screenText(screen tcell.SimulationScreen) string {
+	b, _, _ := screen.GetContents()
+	text := make([]byte, 0, len(b))
+	for _, cell := range b {
+		if len(cell.Bytes) > 0 {
+			text = append(text, cell.Bytes[0])
+		}
+	}
+	return string(text)
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"io"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -9,21 +10,42 @@ import (
 	"github.com/ungtb10d/gdu/v5/pkg/analyze"
 	"github.com/ungtb10d/gdu/v5/pkg/device"
 	"github.com/ungtb10d/gdu/v5/pkg/fs"
+	"github.com/ungtb10d/gdu/v5/pkg/owner"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 const helpText = `    [::b]up/down, k/j    [white:black:-]Move cursor up/down
-  [::b]pgup/pgdn, g/G    [white:black:-]Move cursor top/bottom
+       [::b]pgup/pgdn    [white:black:-]Move cursor page up/down
+     [::b]home/end, G    [white:black:-]Move cursor top/bottom
  [::b]enter, right, l    [white:black:-]Go to directory/device
          [::b]left, h    [white:black:-]Go to parent directory
 
                [::b]r    [white:black:-]Rescan current directory
-               [::b]/    [white:black:-]Search items by name
+               [::b]/    [white:black:-]Filter items by name (text, glob or re:regexp)
+            [::b]:, g    [white:black:-]Jump to path (tab completes names)
+            [::b][, ]    [white:black:-]Go back/forward in history of visited directories
+               [::b]K    [white:black:-]Bookmark current directory
+               [::b]'    [white:black:-]Show bookmarks (enter opens, d removes)
+               [::b]f    [white:black:-]Search the whole tree by name (text, glob or re:regexp)
+               [::b]P    [white:black:-]Keep filter when changing directory, showing all directories (toggle)
                [::b]a    [white:black:-]Toggle between showing disk usage and apparent size
                [::b]B    [white:black:-]Toggle bar alignment to biggest file or directory
                [::b]c    [white:black:-]Show/hide file count
-               [::b]m    [white:black:-]Show/hide latest mtime
+               [::b]m    [white:black:-]Show/hide latest mtime (or atime/ctime)
+               [::b]w    [white:black:-]Switch shown time between mtime, atime and ctime
+               [::b]R    [white:black:-]Show/hide ratio of disk usage to apparent size
+               [::b]o    [white:black:-]Show/hide owner and group
+               [::b]U    [white:black:-]Show usage by users and groups
+               [::b]t    [white:black:-]Show usage by file types (enter shows largest files)
+               [::b]A    [white:black:-]Show usage by age (enter filters older items)
+               [::b]T    [white:black:-]Show only items not modified in given number of days
+               [::b]F    [white:black:-]Show largest files in the whole tree (enter jumps, d deletes, v shows)
+               [::b]V    [white:black:-]Show treemap of current directory (enter descends, backspace goes up)
+               [::b]S    [white:black:-]Show sparse (or compressed) and inflated files
+               [::b]D    [white:black:-]Find duplicate files (d deletes and H hard links all but selected copy)
+               [::b]L    [white:black:-]Show broken symlinks
+               [::b]E    [white:black:-]Show scan errors (enter jumps to the location)
                [::b]b    [white:black:-]Spawn shell in current directory
                [::b]q    [white:black:-]Quit gdu
                [::b]Q    [white:black:-]Quit gdu and print current directory path
@@ -38,7 +60,8 @@ Sort by (twice toggles asc/desc):
                [::b]n    [white:black:-]Sort by name (asc/desc)
                [::b]s    [white:black:-]Sort by size (asc/desc)
                [::b]C    [white:black:-]Sort by file count (asc/desc)
-               [::b]M    [white:black:-]Sort by mtime (asc/desc)`
+               [::b]M    [white:black:-]Sort by shown time (asc/desc)
+               [::b]O    [white:black:-]Sort by owner (asc/desc)`
 
 // UI struct
 type UI struct {
@@ -63,16 +86,29 @@ type UI struct {
 	askBeforeDelete bool
 	showItemCount   bool
 	showMtime       bool
+	timeField       string
+	showOwner       bool
+	showUsageRatio  bool
 	filtering       bool
 	filterValue     string
+	keepFilter      bool
+	startAt         string
+	backHistory     []string
+	forwardHistory  []string
+	bookmarksPath   string
+	olderThan       time.Duration
+	activeView      string
+	footerText      string
 	sortBy          string
 	sortOrder       string
 	done            chan struct{}
 	remover         func(fs.Item, fs.Item) error
 	emptier         func(fs.Item, fs.Item) error
+	linker          func(fs.Item, fs.Item) error
 	getter          device.DevicesInfoGetter
 	exec            func(argv0 string, argv []string, envv []string) error
 	linkedItems     fs.HardLinkedItems
+	resolver        *owner.Resolver
 }
 
 // CreateUI creates the whole UI app
@@ -100,10 +136,14 @@ func CreateUI(
 		output:          output,
 		askBeforeDelete: true,
 		showItemCount:   false,
+		timeField:       "mtime",
 		remover:         analyze.RemoveItemFromDir,
 		emptier:         analyze.EmptyFileFromDir,
+		linker:          analyze.ReplaceWithHardLink,
 		exec:            Execute,
 		linkedItems:     make(fs.HardLinkedItems, 10),
+		resolver:        owner.DefaultResolver,
+		bookmarksPath:   getBookmarksPath(),
 	}
 	ui.resetSorting()
 
@@ -198,8 +238,11 @@ func (ui *UI) fileItemSelected(row, column int) {
 		return
 	}
 
+	ui.pushHistory()
 	ui.currentDir = selectedDir.(*analyze.Dir)
-	ui.hideFilterInput()
+	if !ui.keepFilter {
+		ui.hideFilterInput()
+	}
 	ui.showDir()
 
 	if selectedDir == origDir.GetParent() {
@@ -211,6 +254,24 @@ func (ui *UI) fileItemSelected(row, column int) {
 	}
 }
 
+// jumpTo opens the directory containing the item and selects the item.
+// The top directory is opened itself.
+// It returns false if the item is not shown in the directory (e.g. it is hidden by the age filter).
+func (ui *UI) jumpTo(item fs.Item) bool {
+	ui.pushHistory()
+	if item.GetPath() == ui.topDirPath || item.GetParent() == nil {
+		ui.currentDir = item.(*analyze.Dir)
+		ui.hideFilterInput()
+		ui.showDir()
+		return true
+	}
+
+	ui.currentDir = item.GetParent().(*analyze.Dir)
+	ui.hideFilterInput()
+	ui.showDir()
+	return selectReference(ui.table, item) >= 0
+}
+
 func (ui *UI) deviceItemSelected(row, column int) {
 	var err error
 	selectedDevice := ui.table.GetCell(row, column).GetReference().(*device.Device)
@@ -91,7 +91,7 @@ func TestHelp(t *testing.T) {
 
 	b, _, _ := simScreen.GetContents()
 
-	cells := b[406 : 406+9]
+	cells := b[1068 : 1068+9]
 
 	text := []byte("directory")
 	for i, r := range cells {
@@ -112,7 +112,7 @@ func TestHelpBw(t *testing.T) {
 
 	b, _, _ := simScreen.GetContents()
 
-	cells := b[406 : 406+9]
+	cells := b[1068 : 1068+9]
 
 	text := []byte("directory")
 	for i, r := range cells {
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/ungtb10d/gdu/v5/build"
+)
+
+// createViewTable returns table for a view styled the same way as the main table
+func (ui *UI) createViewTable() *tview.Table {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetBackgroundColor(tcell.ColorDefault)
+
+	if ui.UseColors {
+		table.SetSelectedStyle(tcell.Style{}.
+			Foreground(tview.Styles.TitleColor).
+			Background(tview.Styles.MoreContrastBackgroundColor).Bold(true))
+	} else {
+		table.SetSelectedStyle(tcell.Style{}.
+			Foreground(tcell.ColorWhite).
+			Background(tcell.ColorGray).Bold(true))
+	}
+	return table
+}
+
+// showView shows the table in full screen instead of the directory listing.
+// The view is closed by pressing Esc or q.
+func (ui *UI) showView(name string, title string, table *tview.Table) {
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyESC {
+			ui.closeView()
+			return nil
+		}
+		return event
+	})
+
+	ui.showViewPrimitive(name, title, table)
+}
+
+// showViewPrimitive shows any primitive in full screen instead of the directory listing.
+// The primitive is responsible for closing the view.
+func (ui *UI) showViewPrimitive(name string, title string, primitive tview.Primitive) {
+	if ui.activeView != "" {
+		ui.closeView()
+	}
+
+	ui.footerText = ui.footerLabel.GetText(false)
+	ui.currentDirLabel.SetText("[::b] --- " + tview.Escape(title) + " ---").
+		SetDynamicColors(true)
+
+	grid := tview.NewGrid().SetRows(1, 1, 0, 1).SetColumns(0)
+	grid.AddItem(ui.header, 0, 0, 1, 1, 0, 0, false).
+		AddItem(ui.currentDirLabel, 1, 0, 1, 1, 0, 0, false).
+		AddItem(primitive, 2, 0, 1, 1, 0, 0, true).
+		AddItem(ui.footerLabel, 3, 0, 1, 1, 0, 0, false)
+
+	ui.activeView = name
+	ui.pages.HidePage("background")
+	ui.pages.AddPage(name, grid, true, true)
+	ui.app.SetFocus(primitive)
+}
+
+// closeView closes currently shown view and returns back to the directory listing
+func (ui *UI) closeView() {
+	if ui.activeView == "" {
+		return
+	}
+
+	ui.pages.RemovePage(ui.activeView)
+	ui.pages.ShowPage("background")
+	ui.activeView = ""
+
+	ui.footerLabel.SetText(ui.footerText)
+	ui.currentDirLabel.SetText("[::b] --- " +
+		tview.Escape(strings.TrimPrefix(ui.currentDirPath, build.RootPathPrefix)) +
+		" ---").SetDynamicColors(true)
+	ui.app.SetFocus(ui.table)
+}
+
+// addViewHeader adds non-selectable bold row into the view table and returns index of next row
+func addViewHeader(table *tview.Table, row int, text string) int {
+	cell := tview.NewTableCell("[::b]" + text)
+	cell.SetSelectable(false)
+	cell.SetStyle(tcell.Style{}.Foreground(tcell.ColorDefault))
+	table.SetCell(row, 0, cell)
+	return row + 1
+}
+
+// selectReference selects row of the table referencing given item and returns its index, -1 if not found
+func selectReference(table *tview.Table, ref interface{}) int {
+	for row := 0; row < table.GetRowCount(); row++ {
+		if table.GetCell(row, 0).GetReference() == ref {
+			table.Select(row, 0)
+			return row
+		}
+	}
+	return -1
+}